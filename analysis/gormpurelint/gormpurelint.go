@@ -0,0 +1,379 @@
+// Package gormpurelint implements a go/analysis.Analyzer built from the
+// scripts/methods survey's EnumerationResult: it flags call chains that
+// invoke a method classified "Mutating" or "Unknown" (see
+// analysis/ssapurity) on a *gorm.DB value obtained from a shared source -
+// a package-level var, a struct field, or a function parameter - without
+// an intervening .Session(&gorm.Session{NewDB: true}) to fork an isolated
+// copy first. This is the same session-pollution problem
+// analysis/gormpurity documents via hand-placed //gormpurity:base
+// directives, caught instead from the survey's ground truth with no
+// annotations required.
+//
+// A pollutesParamFact is exported for user functions whose *gorm.DB
+// parameter is itself mutated in this way, so pollution is tracked across
+// package boundaries: passing a shared *gorm.DB into such a function is
+// flagged the same as calling a Mutating method directly.
+package gormpurelint
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer is the gormpurelint go/analysis.Analyzer. Run standalone via
+// cmd/gormpurelint (golang.org/x/tools/go/analysis/singlechecker, which
+// also provides the `-fix` flag that applies this analyzer's
+// SuggestedFixes), or loaded as a golangci-lint custom plugin via
+// AnalyzerPlugin.
+var Analyzer = &analysis.Analyzer{
+	Name:      "gormpurelint",
+	Doc:       "flags *gorm.DB chains from a shared source that call survey-Mutating/Unknown methods without forking a Session first",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(pollutesParamFact)},
+}
+
+// enumerationFlag is registered on Analyzer.Flags so both singlechecker
+// (cmd/gormpurelint) and golangci-lint (via AnalyzerPlugin) can point the
+// analyzer at a freshly generated EnumerationResult without code changes.
+var enumerationFlag string
+
+func init() {
+	Analyzer.Flags.StringVar(&enumerationFlag, "enumeration", "", "path to a freshly generated EnumerationResult JSON to widen the built-in survey data")
+}
+
+// enumeration.json is the output of `go run ./scripts/methods` against
+// the GORM version this module depends on, embedded verbatim so the
+// built-in flaggedMethods set reflects analysis/ssapurity's real SSA
+// classification for every *gorm.DB method rather than a hand-picked
+// subset. Re-run that command and re-embed whenever the GORM dependency
+// is bumped, to pick up any newly added or reclassified methods;
+// LoadEnumerationFile covers the gap in the meantime.
+//
+//go:embed enumeration.json
+var embeddedEnumeration []byte
+
+// enumerationResult mirrors the subset of scripts/methods'
+// EnumerationResult this analyzer needs.
+type enumerationResult struct {
+	Types map[string]struct {
+		Methods []struct {
+			Name              string `json:"name"`
+			SSAClassification string `json:"ssa_classification"`
+		} `json:"methods"`
+	} `json:"types"`
+}
+
+// flaggedMethods holds *gorm.DB method names the survey classified as
+// Mutating or Unknown - i.e. known or unproven-safe to call on a shared
+// handle. It starts from the embedded enumeration.json and can be widened
+// at runtime with LoadEnumerationFile.
+var flaggedMethods = flaggedMethodsFrom(embeddedEnumeration)
+
+// LoadEnumerationFile widens flaggedMethods with a freshly generated
+// EnumerationResult JSON (e.g. `go run ./scripts/methods > report.json`),
+// so the analyzer can pick up survey results newer than whatever was
+// embedded at build time.
+func LoadEnumerationFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("gormpurelint: reading %s: %w", path, err)
+	}
+	for name := range flaggedMethodsFrom(data) {
+		flaggedMethods[name] = true
+	}
+	return nil
+}
+
+func flaggedMethodsFrom(data []byte) map[string]bool {
+	set := make(map[string]bool)
+	var er enumerationResult
+	if err := json.Unmarshal(data, &er); err != nil {
+		return set
+	}
+	tm, ok := er.Types["*gorm.DB"]
+	if !ok {
+		return set
+	}
+	for _, m := range tm.Methods {
+		if m.SSAClassification == "Mutating" || m.SSAClassification == "Unknown" {
+			set[m.Name] = true
+		}
+	}
+	return set
+}
+
+// pollutesParamFact marks a function parameter object whose *gorm.DB
+// value the function body mutates (by calling a flagged method on it
+// without first forking a Session) - i.e. passing a shared *gorm.DB into
+// a function carrying this fact on that parameter pollutes the caller's
+// copy too.
+type pollutesParamFact struct{ Pollutes bool }
+
+func (*pollutesParamFact) AFact() {}
+func (f *pollutesParamFact) String() string {
+	return fmt.Sprintf("pollutesParam(%v)", f.Pollutes)
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if enumerationFlag != "" {
+		if err := LoadEnumerationFile(enumerationFlag); err != nil {
+			return nil, err
+		}
+		enumerationFlag = "" // widen exactly once, not per analyzed package
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// First pass: export pollutesParamFact for this package's own
+	// functions, so callers elsewhere (including other packages, once
+	// facts are loaded for their imports) see it on ImportObjectFact.
+	insp.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		exportParamPollutionFacts(pass, n.(*ast.FuncDecl))
+	})
+
+	// Second pass: flag chains rooted at a shared *gorm.DB source.
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		checkSharedChain(pass, n.(*ast.CallExpr))
+	})
+
+	return nil, nil
+}
+
+// exportParamPollutionFacts checks each *gorm.DB parameter of fn: if the
+// body calls a flagged method on that parameter before any
+// .Session(&gorm.Session{NewDB: true}) fork, export pollutesParamFact on
+// the parameter object.
+func exportParamPollutionFacts(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if fn.Body == nil || fn.Type.Params == nil {
+		return
+	}
+	for _, field := range fn.Type.Params.List {
+		for _, name := range field.Names {
+			obj, ok := pass.TypesInfo.Defs[name].(*types.Var)
+			if !ok || !isGormDB(obj.Type()) {
+				continue
+			}
+			if pollutesIdent(pass, fn.Body, obj) {
+				pass.ExportObjectFact(obj, &pollutesParamFact{Pollutes: true})
+			}
+		}
+	}
+}
+
+// pollutesIdent reports whether body calls a flagged method directly on
+// v without first calling .Session(&gorm.Session{NewDB: true}) on it.
+func pollutesIdent(pass *analysis.Pass, body ast.Node, v *types.Var) bool {
+	forked := make(map[*types.Var]bool)
+	polluted := false
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		recvIdent, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		recv, ok := pass.TypesInfo.Uses[recvIdent].(*types.Var)
+		if !ok || recv != v {
+			return true
+		}
+		if sel.Sel.Name == "Session" {
+			forked[v] = true
+			return true
+		}
+		if flaggedMethods[sel.Sel.Name] && !forked[v] {
+			polluted = true
+		}
+		return true
+	})
+	return polluted
+}
+
+// checkSharedChain flags a flagged method called directly on a *gorm.DB
+// obtained from a shared source (package-level var, struct field, or
+// function parameter), or such a *gorm.DB passed directly as an argument
+// into a function carrying pollutesParamFact on that parameter. Only the
+// immediate receiver or argument expression is inspected: a flagged call
+// whose receiver is itself a call result (e.g. db.Where(...).AddError) is
+// not flagged, whether or not a .Session fork appears in that chain.
+func checkSharedChain(pass *analysis.Pass, call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if ok && flaggedMethods[sel.Sel.Name] {
+		if recv, isShared := sharedGormDB(pass, sel.X); isShared {
+			pass.Report(analysis.Diagnostic{
+				Pos: call.Pos(),
+				Message: fmt.Sprintf(
+					"%s is shared (not a fresh Session) and %s is classified Mutating/Unknown by the purity survey; fork with .Session(&gorm.Session{NewDB: true}) first",
+					recv, sel.Sel.Name,
+				),
+				SuggestedFixes: []analysis.SuggestedFix{sessionForkFix(sel)},
+			})
+		}
+		return
+	}
+
+	// Calling a user function known (via fact) to pollute one of its
+	// *gorm.DB parameters, with a shared *gorm.DB argument.
+	callee, ok := typeutilCallee(pass, call)
+	if !ok {
+		return
+	}
+	sig, ok := callee.Type().(*types.Signature)
+	if !ok {
+		return
+	}
+	for i, arg := range call.Args {
+		if i >= sig.Params().Len() {
+			break
+		}
+		param := sig.Params().At(i)
+		var fact pollutesParamFact
+		if !pass.ImportObjectFact(param, &fact) || !fact.Pollutes {
+			continue
+		}
+		if recv, isShared := sharedGormDB(pass, arg); isShared {
+			pass.Reportf(arg.Pos(),
+				"%s is shared (not a fresh Session) and is passed into %s, which the survey shows mutates this parameter; fork with .Session(&gorm.Session{NewDB: true}) first",
+				recv, callee.Name())
+		}
+	}
+}
+
+// sharedGormDB reports whether expr is a *gorm.DB obtained from a shared
+// source - an identifier resolving to a package-level var, a struct
+// field selector, or a function parameter - as opposed to a fresh local
+// returned by a Session-style fork. It returns a short description of the
+// source for diagnostics.
+func sharedGormDB(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	t := pass.TypesInfo.TypeOf(expr)
+	if t == nil || !isGormDB(t) {
+		return "", false
+	}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		v, ok := pass.TypesInfo.Uses[e].(*types.Var)
+		if !ok {
+			return "", false
+		}
+		if v.IsField() {
+			return e.Name, true
+		}
+		if v.Parent() == v.Pkg().Scope() {
+			return "package-level var " + v.Name(), true
+		}
+		// A parameter is treated as shared; any other local is not,
+		// whatever it was assigned from. checkSharedChain's callers only
+		// reach here via a direct method-call receiver, so a bare
+		// identifier is either a parameter (shared) or a local holding
+		// the result of an earlier chain call (already covered by the
+		// call-site check on that earlier call).
+		if _, isParam := paramOf(pass, v); isParam {
+			return "parameter " + v.Name(), true
+		}
+		return "", false
+	case *ast.SelectorExpr:
+		if _, ok := pass.TypesInfo.Selections[e]; ok {
+			return e.Sel.Name, true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// paramOf reports whether v is a function parameter (as opposed to a
+// local variable), which go/types doesn't distinguish directly.
+func paramOf(pass *analysis.Pass, v *types.Var) (*types.Var, bool) {
+	for _, f := range pass.Files {
+		var isParam bool
+		ast.Inspect(f, func(n ast.Node) bool {
+			fn, ok := n.(*ast.FuncDecl)
+			if !ok || fn.Type.Params == nil {
+				return true
+			}
+			for _, field := range fn.Type.Params.List {
+				for _, name := range field.Names {
+					if obj, ok := pass.TypesInfo.Defs[name].(*types.Var); ok && obj == v {
+						isParam = true
+					}
+				}
+			}
+			return true
+		})
+		if isParam {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// typeutilCallee resolves the *types.Func a call invokes, if statically
+// known (a plain or qualified identifier, not a method value/interface
+// call).
+func typeutilCallee(pass *analysis.Pass, call *ast.CallExpr) (*types.Func, bool) {
+	var ident *ast.Ident
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		ident = fn
+	case *ast.SelectorExpr:
+		ident = fn.Sel
+	default:
+		return nil, false
+	}
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	return fn, ok
+}
+
+func isGormDB(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	return named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "gorm.io/gorm" && named.Obj().Name() == "DB"
+}
+
+// sessionForkFix suggests inserting .Session(&gorm.Session{NewDB: true})
+// right before the flagged method call, mirroring
+// analysis/gormpurity's sessionSplitFix.
+func sessionForkFix(sel *ast.SelectorExpr) analysis.SuggestedFix {
+	return analysis.SuggestedFix{
+		Message: "insert .Session(&gorm.Session{NewDB: true}) before " + sel.Sel.Name,
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     sel.X.End(),
+				End:     sel.X.End(),
+				NewText: []byte(".Session(&gorm.Session{NewDB: true})"),
+			},
+		},
+	}
+}
+
+// AnalyzerPlugin is golangci-lint's documented module-plugin entry point:
+// a value named AnalyzerPlugin whose GetAnalyzers method returns the
+// analyzers to register.
+var AnalyzerPlugin analyzerPlugin
+
+type analyzerPlugin struct{}
+
+func (analyzerPlugin) GetAnalyzers() []*analysis.Analyzer {
+	return []*analysis.Analyzer{Analyzer}
+}
@@ -0,0 +1,18 @@
+package gormpurelint_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/mpyw/gorm-purity-survey/analysis/gormpurelint"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	// "a" asserts the genuine-misuse diagnostics; "b" is the golden case -
+	// idiomatic Where/Find chains on the same shared-DB shapes, which must
+	// produce zero diagnostics now that those methods are classified
+	// CopyOnWrite rather than Mutating.
+	analysistest.Run(t, testdata, gormpurelint.Analyzer, "a", "b")
+}
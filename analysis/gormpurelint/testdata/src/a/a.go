@@ -0,0 +1,29 @@
+package a
+
+import "gorm.io/gorm"
+
+var sharedDB *gorm.DB
+
+type holder struct {
+	db *gorm.DB
+}
+
+func packageLevelVar() {
+	sharedDB.AddError(nil) // want `package-level var sharedDB is shared`
+}
+
+func structField(h *holder) {
+	h.db.AddError(nil) // want `db is shared`
+}
+
+func sharedParam(db *gorm.DB) { // want db:`pollutesParam\(true\)`
+	db.AddError(nil) // want `parameter db is shared`
+}
+
+func forkedFirst(db *gorm.DB) {
+	db.Session(&gorm.Session{NewDB: true}).AddError(nil)
+}
+
+func passesSharedIntoPolluter() {
+	sharedParam(sharedDB) // want `package-level var sharedDB is shared .* passed into sharedParam`
+}
@@ -0,0 +1,27 @@
+// Package b mirrors package a's shared-*gorm.DB shapes (package-level var,
+// struct field, parameter) but chains through Where/Find - both
+// CopyOnWrite per the purity survey - instead of a's AddError. It's the
+// golden case: ordinary, idiomatic query-building on a shared handle is
+// exactly what gormpurelint must NOT flag, which is why it carries no
+// `want` comments at all.
+package b
+
+import "gorm.io/gorm"
+
+var sharedDB *gorm.DB
+
+type holder struct {
+	db *gorm.DB
+}
+
+func packageLevelVar() *gorm.DB {
+	return sharedDB.Where("x = ?", 1).Find(nil)
+}
+
+func structField(h *holder) *gorm.DB {
+	return h.db.Where("x = ?", 1).Find(nil)
+}
+
+func sharedParam(db *gorm.DB) *gorm.DB {
+	return db.Where("x = ?", 1).Find(nil)
+}
@@ -0,0 +1,322 @@
+// Package gormpurity implements a go/analysis Analyzer that flags unsafe
+// reuse of a *gorm.DB value across chain-method calls known (from the
+// methods.Methods registry and an optional impure_methods.json)
+// to pollute their receiver.
+//
+// A variable is tracked once it is marked with a `//gormpurity:base` comment
+// on its declaring statement:
+//
+//	base := db.Model(&User{}) //gormpurity:base
+//	base.Where("role = ?", "admin") // flagged: pollutes base, which Find reuses below
+//	base.Find(&users)
+//
+// Reuse after a flagged call can be silenced per-call with
+// `//gormpurity:ignore`:
+//
+//	base.Where("role = ?", "admin") //gormpurity:ignore
+//
+// The analyzer also flags callback bodies passed to Scopes, Transaction,
+// and FindInBatches that mutate their received *gorm.DB without returning
+// it, since GORM does not propagate those mutations back to the caller.
+package gormpurity
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/mpyw/gorm-purity-survey/methods"
+)
+
+// Analyzer is the gormpurity go/analysis.Analyzer.
+var Analyzer = &analysis.Analyzer{
+	Name:     "gormpurity",
+	Doc:      "flags unsafe reuse of a *gorm.DB marked //gormpurity:base across pollution-prone chain methods",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// pollutingMethods holds the chain-category method names treated as
+// pollution-prone ground truth. It is seeded from methods.ChainMethods()
+// and can be widened with entries from a generated impure_methods.json via
+// AddImpureMethods.
+var pollutingMethods = func() map[string]bool {
+	set := make(map[string]bool)
+	for _, m := range methods.ChainMethods() {
+		set[m.Name] = true
+	}
+	return set
+}()
+
+// callbackMethods holds the names of methods whose func(*gorm.DB) callback
+// argument must return the (possibly mutated) *gorm.DB to have any effect.
+var callbackMethods = map[string]bool{
+	"Scopes":        true,
+	"Transaction":   true,
+	"FindInBatches": true,
+}
+
+// AddImpureMethods widens pollutingMethods with extra names, such as those
+// read from an impure_methods.json by LoadImpureMethods, beyond the
+// hand-curated methods.Methods table.
+func AddImpureMethods(names []string) {
+	for _, n := range names {
+		pollutingMethods[n] = true
+	}
+}
+
+const (
+	gormDBType      = "*gorm.DB"
+	baseDirective   = "gormpurity:base"
+	ignoreDirective = "gormpurity:ignore"
+)
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+		if body == nil {
+			return
+		}
+		checkBaseReuse(pass, body)
+	})
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		checkCallbackMutation(pass, n.(*ast.CallExpr))
+	})
+
+	return nil, nil
+}
+
+// checkBaseReuse walks a function body looking for `//gormpurity:base`
+// variables and flags any call to a polluting chain method on that
+// variable followed by a later read of the same variable.
+func checkBaseReuse(pass *analysis.Pass, body *ast.BlockStmt) {
+	baseVars := make(map[*types.Var]bool)
+
+	for i, stmt := range body.List {
+		if hasDirective(pass, stmt, baseDirective) {
+			for _, v := range identsAssignedIn(pass, stmt) {
+				baseVars[v] = true
+			}
+		}
+		if len(baseVars) == 0 {
+			continue
+		}
+
+		call, recv, ok := pollutingCallOn(pass, stmt, baseVars)
+		if !ok || hasDirective(pass, stmt, ignoreDirective) {
+			continue
+		}
+
+		if laterStmtReads(pass, body.List[i+1:], recv) {
+			pass.Report(analysis.Diagnostic{
+				Pos: call.Pos(),
+				Message: fmt.Sprintf(
+					"%s pollutes the receiver; %s is reused afterward (suggested: wrap with .Session(&gorm.Session{NewDB: true}) before calling %s)",
+					calleeName(call), recv.Name(), calleeName(call),
+				),
+				SuggestedFixes: []analysis.SuggestedFix{
+					sessionSplitFix(pass, call),
+				},
+			})
+		}
+	}
+}
+
+// pollutingCallOn reports whether stmt is (or contains, for ExprStmt) a
+// call to a polluting chain method whose receiver is one of baseVars, and
+// returns that receiver variable.
+func pollutingCallOn(pass *analysis.Pass, stmt ast.Stmt, baseVars map[*types.Var]bool) (*ast.CallExpr, *types.Var, bool) {
+	var expr ast.Expr
+	switch s := stmt.(type) {
+	case *ast.ExprStmt:
+		expr = s.X
+	case *ast.AssignStmt:
+		if len(s.Rhs) != 1 {
+			return nil, nil, false
+		}
+		expr = s.Rhs[0]
+	default:
+		return nil, nil, false
+	}
+
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil, nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !pollutingMethods[sel.Sel.Name] {
+		return nil, nil, false
+	}
+	recvIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	v, ok := pass.TypesInfo.Uses[recvIdent].(*types.Var)
+	if !ok || !baseVars[v] {
+		return nil, nil, false
+	}
+	return call, v, true
+}
+
+// laterStmtReads reports whether v is read by any of the given statements.
+func laterStmtReads(pass *analysis.Pass, stmts []ast.Stmt, v *types.Var) bool {
+	found := false
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if use, ok := pass.TypesInfo.Uses[id].(*types.Var); ok && use == v {
+				found = true
+			}
+			return true
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// identsAssignedIn returns the *types.Var for each identifier on the LHS of
+// an assignment or short variable declaration statement.
+func identsAssignedIn(pass *analysis.Pass, stmt ast.Stmt) []*types.Var {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok {
+		return nil
+	}
+	var out []*types.Var
+	for _, lhs := range assign.Lhs {
+		id, ok := lhs.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok {
+			out = append(out, v)
+		} else if v, ok := pass.TypesInfo.Uses[id].(*types.Var); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// checkCallbackMutation flags func(*gorm.DB) ... literals passed to
+// Scopes/Transaction/FindInBatches whose parameter is used as the receiver
+// of a polluting chain method but whose result is discarded.
+func checkCallbackMutation(pass *analysis.Pass, call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !callbackMethods[sel.Sel.Name] {
+		return
+	}
+
+	for _, arg := range call.Args {
+		lit, ok := arg.(*ast.FuncLit)
+		if !ok || len(lit.Type.Params.List) == 0 {
+			continue
+		}
+		param := lit.Type.Params.List[0]
+		if len(param.Names) == 0 {
+			continue
+		}
+		paramObj, ok := pass.TypesInfo.Defs[param.Names[0]].(*types.Var)
+		if !ok || !isGormDB(paramObj.Type()) {
+			continue
+		}
+
+		for _, stmt := range lit.Body.List {
+			exprStmt, ok := stmt.(*ast.ExprStmt)
+			if !ok {
+				continue
+			}
+			innerCall, ok := exprStmt.X.(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			innerSel, ok := innerCall.Fun.(*ast.SelectorExpr)
+			if !ok || !pollutingMethods[innerSel.Sel.Name] {
+				continue
+			}
+			recvIdent, ok := innerSel.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if v, ok := pass.TypesInfo.Uses[recvIdent].(*types.Var); !ok || v != paramObj {
+				continue
+			}
+			pass.Reportf(exprStmt.Pos(),
+				"%s callback calls %s on %s and discards the result; GORM does not propagate the mutation back, return it instead",
+				sel.Sel.Name, innerSel.Sel.Name, paramObj.Name())
+		}
+	}
+}
+
+func isGormDB(t types.Type) bool {
+	return t.String() == gormDBType
+}
+
+func calleeName(call *ast.CallExpr) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "?"
+	}
+	return sel.Sel.Name
+}
+
+// sessionSplitFix suggests inserting .Session(&gorm.Session{NewDB: true})
+// at the split point, i.e. right before the flagged method call.
+func sessionSplitFix(pass *analysis.Pass, call *ast.CallExpr) analysis.SuggestedFix {
+	sel := call.Fun.(*ast.SelectorExpr)
+	return analysis.SuggestedFix{
+		Message: "insert .Session(&gorm.Session{NewDB: true}) before " + sel.Sel.Name,
+		TextEdits: []analysis.TextEdit{
+			{
+				Pos:     sel.X.End(),
+				End:     sel.X.End(),
+				NewText: []byte(".Session(&gorm.Session{NewDB: true})"),
+			},
+		},
+	}
+}
+
+// hasDirective reports whether a comment starting on the line where stmt
+// ends (its trailing line comment) contains the given `//gormpurity:...`
+// directive. Comments on earlier or later lines are not considered.
+// go/analysis passes don't thread comment maps through automatically, so
+// this walks pass.Files' comment groups itself.
+func hasDirective(pass *analysis.Pass, stmt ast.Stmt, directive string) bool {
+	endLine := pass.Fset.Position(stmt.End()).Line
+	for _, f := range pass.Files {
+		if stmt.Pos() < f.Pos() || stmt.Pos() > f.End() {
+			continue
+		}
+		for _, cg := range f.Comments {
+			if cg.Pos() < stmt.Pos() || pass.Fset.Position(cg.Pos()).Line != endLine {
+				continue
+			}
+			if strings.Contains(cg.Text(), directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,14 @@
+package gormpurity_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/mpyw/gorm-purity-survey/analysis/gormpurity"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, gormpurity.Analyzer, "a")
+}
@@ -0,0 +1,32 @@
+package gormpurity
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// impureMethodsFile is the schema LoadImpureMethods reads: a JSON object
+// whose "methods" field is a flat list of method names to treat as
+// polluting their receiver, beyond what methods.Methods hand-curates.
+// Nothing in this module writes a file of this shape; the JSON that
+// `go test -run TestSurvey -args -report=...` writes is a report.Report,
+// which LoadImpureMethods does not read.
+type impureMethodsFile struct {
+	Methods []string `json:"methods"`
+}
+
+// LoadImpureMethods reads a generated impure_methods.json and widens the
+// analyzer's pollutingMethods set with its contents.
+func LoadImpureMethods(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("gormpurity: reading %s: %w", path, err)
+	}
+	var f impureMethodsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("gormpurity: parsing %s: %w", path, err)
+	}
+	AddImpureMethods(f.Methods)
+	return nil
+}
@@ -0,0 +1,22 @@
+package a
+
+import "gorm.io/gorm"
+
+func reusedAfterPollution(db *gorm.DB) {
+	base := db.Where("x = ?", 1) //gormpurity:base
+	base.Where("y = ?", 2)       // want `Where pollutes the receiver`
+	base.Find(&struct{}{})
+}
+
+func ignoredViaDirective(db *gorm.DB) {
+	base := db.Where("x = ?", 1) //gormpurity:base
+	base.Where("y = ?", 2)       //gormpurity:ignore
+	base.Find(&struct{}{})
+}
+
+func scopesDiscardsMutation(db *gorm.DB) {
+	db.Scopes(func(tx *gorm.DB) *gorm.DB {
+		tx.Where("z = ?", 3) // want `Scopes callback calls Where`
+		return tx
+	})
+}
@@ -0,0 +1,15 @@
+// Package gorm is a minimal stand-in for gorm.io/gorm, just enough surface
+// area for analysistest to type-check the gormpurity test fixtures against.
+package gorm
+
+type DB struct{}
+
+func (db *DB) Where(query interface{}, args ...interface{}) *DB { return db }
+func (db *DB) Find(dest interface{}, conds ...interface{}) *DB  { return db }
+func (db *DB) Session(s *Session) *DB                           { return db }
+func (db *DB) Scopes(fns ...func(*DB) *DB) *DB                  { return db }
+func (db *DB) Transaction(fc func(tx *DB) error) error          { return fc(db) }
+
+type Session struct {
+	NewDB bool
+}
@@ -0,0 +1,337 @@
+// Package ssapurity replaces the returns-*gorm.DB heuristic in
+// scripts/methods (which can only see a method's signature and has to
+// assume anything returning *gorm.DB is a "chain point") with a real
+// intra-procedural data-flow analysis: it loads a package with
+// golang.org/x/tools/go/packages, builds its SSA form with
+// golang.org/x/tools/go/ssa, and for every method whose receiver is
+// *gorm.DB walks the function body to see whether it actually stores
+// through the receiver and whether its return value actually aliases it.
+//
+// This also reaches unexported methods, which the reflection-based
+// enumerator in scripts/methods can't see at all.
+package ssapurity
+
+import (
+	"fmt"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// Classification is the SSA-derived purity verdict for one *gorm.DB method.
+type Classification string
+
+const (
+	// ClassPure means the method stores nothing derived from the
+	// receiver and its return value does not alias the receiver.
+	ClassPure Classification = "Pure"
+
+	// ClassCopyOnWrite means the method stores through a pointer derived
+	// from a fresh value (e.g. a Session()-style copy), not the
+	// receiver, and returns that fresh value. A method that stores
+	// nothing and returns the receiver itself is also classified
+	// CopyOnWrite.
+	ClassCopyOnWrite Classification = "CopyOnWrite"
+
+	// ClassMutating means the method stores through a pointer derived
+	// from the receiver itself.
+	ClassMutating Classification = "Mutating"
+
+	// ClassUnknown means the analysis could not reach a conclusion, e.g.
+	// because the method has no SSA body (an external declaration) or
+	// its signature doesn't match what the walk expects.
+	ClassUnknown Classification = "Unknown"
+)
+
+// MethodClassification is one *gorm.DB method's SSA-derived result.
+type MethodClassification struct {
+	Name   string `json:"name"`
+	// MutatesReceiver and ReturnsReceiver describe the dangerous case:
+	// the method stores through, or returns, the receiver's own memory
+	// directly, with no intervening getInstance()/NewDB() call.
+	MutatesReceiver bool `json:"mutates_receiver"`
+	ReturnsReceiver bool `json:"returns_receiver"`
+	// MutatesCopy and ReturnsCopy describe the standard GORM chain
+	// pattern: tx := db.getInstance(); tx.Statement... = ...; return tx.
+	// getInstance()/NewDB() may hand back either a fresh clone or the
+	// receiver itself depending on runtime state (the clone field), so a
+	// store/return traced only as far as one of these calls is distinct
+	// from - and much less dangerous than - storing through or returning
+	// the receiver with no copy constructor in between at all.
+	MutatesCopy    bool           `json:"mutates_copy"`
+	ReturnsCopy    bool           `json:"returns_copy"`
+	Classification Classification `json:"classification"`
+	Note           string         `json:"note,omitempty"`
+}
+
+// AnalyzePackage loads pkgPath (typically "gorm.io/gorm"), builds its SSA
+// form, and classifies every method whose receiver is *gorm.DB (any
+// exported or unexported method declared on that type within pkgPath).
+func AnalyzePackage(pkgPath string) ([]MethodClassification, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedDeps | packages.NeedTypesInfo | packages.NeedImports,
+	}
+	pkgs, err := packages.Load(cfg, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("ssapurity: loading %s: %w", pkgPath, err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("ssapurity: %s failed to type-check", pkgPath)
+	}
+
+	prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.SanityCheckFunctions)
+	prog.Build()
+
+	var target *ssa.Package
+	for i, p := range pkgs {
+		if p.PkgPath == pkgPath {
+			target = ssaPkgs[i]
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("ssapurity: %s not found after SSA build", pkgPath)
+	}
+
+	var out []MethodClassification
+	for fn := range ssautil.AllFunctions(prog) {
+		if fn.Pkg != target || !hasDBReceiver(fn, pkgPath) {
+			continue
+		}
+		out = append(out, classifyMethod(fn))
+	}
+	return out, nil
+}
+
+// hasDBReceiver reports whether fn is a method with a *DB receiver
+// declared in pkgPath itself (as opposed to an instantiation or a method
+// on an unrelated type). Named "DB" rather than "gorm.io/gorm"-specific so
+// AnalyzePackage's own tests can point it at a small stand-in package
+// instead of the real gorm.io/gorm source.
+func hasDBReceiver(fn *ssa.Function, pkgPath string) bool {
+	recv := fn.Signature.Recv()
+	if recv == nil {
+		return false
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	return named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == pkgPath && named.Obj().Name() == "DB"
+}
+
+// taintKind classifies how an SSA value derives from the receiver: not at
+// all, directly (field/index/deref derivations of the receiver's own
+// memory, never crossing a getInstance()/NewDB() call), or via a copy (the
+// value, or some ancestor of it, came back out of a getInstance()/NewDB()
+// call fed by a direct-or-copy-derived argument). getInstance()/NewDB() may
+// hand back either a fresh clone or the receiver itself depending on
+// runtime state (the clone field), so once a value has passed through one
+// it's only ever "derived from a copy", never "direct", however many more
+// field derivations follow.
+type taintKind int
+
+const (
+	taintNone taintKind = iota
+	taintDirect
+	taintCopy
+)
+
+// classifyMethod runs the intra-procedural alias walk described in the
+// package doc: it seeds the receiver parameter as directly tainted,
+// propagates that taint (direct or, once a getInstance/NewDB call is
+// crossed, copy) through field/index/deref/call derivations, then checks
+// whether any *ssa.Store targets a tainted address and whether any
+// returned value traces back to the receiver - and whether each was via a
+// copy constructor or the receiver's own memory directly.
+func classifyMethod(fn *ssa.Function) MethodClassification {
+	mc := MethodClassification{Name: fn.Name(), Classification: ClassUnknown}
+
+	if fn.Blocks == nil {
+		mc.Note = "no SSA body (external declaration)"
+		return mc
+	}
+	if len(fn.Params) == 0 {
+		mc.Note = "method has no receiver parameter in SSA form"
+		return mc
+	}
+	recv := fn.Params[0]
+
+	tainted := map[ssa.Value]taintKind{recv: taintDirect}
+	for changed := true; changed; {
+		changed = false
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				v, _ := instr.(ssa.Value)
+				if v == nil || tainted[v] != taintNone {
+					continue
+				}
+				switch t := instr.(type) {
+				case *ssa.FieldAddr:
+					if k := tainted[t.X]; k != taintNone {
+						tainted[v] = k
+						changed = true
+					}
+				case *ssa.IndexAddr:
+					if k := tainted[t.X]; k != taintNone {
+						tainted[v] = k
+						changed = true
+					}
+				case *ssa.UnOp:
+					if k := tainted[t.X]; k != taintNone {
+						tainted[v] = k
+						changed = true
+					}
+				case *ssa.Call:
+					// Every real chain method funnels through
+					// getInstance()/NewDB() before mutating anything:
+					// tx := db.getInstance(); tx.Statement... = ...;
+					// return tx. Without this case tx never picks up
+					// the receiver's taint and the walk below sees no
+					// tainted store and no tainted return, misreading
+					// the method as pure. The result always lands in
+					// taintCopy, even if the argument was taintDirect:
+					// crossing the constructor is exactly what makes it
+					// a (possibly fresh) copy instead of the receiver
+					// itself.
+					if isInstanceConstructor(&t.Call) && anyTainted(t.Call.Args, tainted) {
+						tainted[v] = taintCopy
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			store, ok := instr.(*ssa.Store)
+			if !ok {
+				continue
+			}
+			switch tainted[store.Addr] {
+			case taintDirect:
+				mc.MutatesReceiver = true
+			case taintCopy:
+				mc.MutatesCopy = true
+			}
+		}
+	}
+
+	for _, b := range fn.Blocks {
+		ret, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return)
+		if !ok {
+			continue
+		}
+		for _, res := range ret.Results {
+			switch traceToReceiver(res, recv) {
+			case taintDirect:
+				mc.ReturnsReceiver = true
+			case taintCopy:
+				mc.ReturnsCopy = true
+			}
+		}
+	}
+
+	switch {
+	case !mc.MutatesReceiver && !mc.MutatesCopy && !mc.ReturnsReceiver && !mc.ReturnsCopy:
+		mc.Classification = ClassPure
+	case mc.MutatesReceiver && mc.ReturnsReceiver:
+		mc.Classification = ClassMutating
+		mc.Note = "stores through and returns the receiver's own memory directly, with no getInstance/NewDB copy in between"
+	case mc.MutatesReceiver:
+		mc.Classification = ClassMutating
+		mc.Note = "stores through the receiver's own memory directly, with no getInstance/NewDB copy in between"
+	case mc.MutatesCopy || mc.ReturnsCopy:
+		mc.Classification = ClassCopyOnWrite
+		mc.Note = "stores through and/or returns a getInstance/NewDB-derived copy, not the receiver's own memory"
+	default:
+		mc.Classification = ClassCopyOnWrite
+		mc.Note = "returns the receiver directly without storing through it"
+	}
+	return mc
+}
+
+// traceToReceiver walks v back through unary/conversion/field/index
+// derivations, and through calls to getInstance()/NewDB() (the
+// copy-on-write constructors every chain method returns through), to see
+// whether it is ultimately the receiver itself. It returns taintDirect if
+// that chain never crosses a getInstance/NewDB call (a literal alias of
+// the receiver), taintCopy if it does (a value that may be a fresh clone
+// rather than the receiver), and taintNone if v doesn't trace back to recv
+// at all.
+func traceToReceiver(v ssa.Value, recv ssa.Value) taintKind {
+	seen := make(map[ssa.Value]bool)
+	kind := taintDirect
+	for v != nil && !seen[v] {
+		if v == recv {
+			return kind
+		}
+		seen[v] = true
+		switch x := v.(type) {
+		case *ssa.UnOp:
+			v = x.X
+		case *ssa.ChangeType:
+			v = x.X
+		case *ssa.Convert:
+			v = x.X
+		case *ssa.FieldAddr:
+			v = x.X
+		case *ssa.IndexAddr:
+			v = x.X
+		case *ssa.Call:
+			if !isInstanceConstructor(&x.Call) || len(x.Call.Args) == 0 {
+				return taintNone
+			}
+			kind = taintCopy
+			v = x.Call.Args[0]
+		default:
+			return taintNone
+		}
+	}
+	return taintNone
+}
+
+// isInstanceConstructor reports whether call is a statically-resolved
+// call to gorm.io/gorm's getInstance or NewDB: the two copy-on-write
+// constructors every real chain method funnels through before touching
+// its Statement. A value derived from one of these is tainted taintCopy,
+// kept apart from values derived directly from the receiver, since
+// whether it is a fresh clone or the receiver itself depends on the
+// callee's clone field at runtime - not something this intra-procedural
+// walk can resolve.
+func isInstanceConstructor(call *ssa.CallCommon) bool {
+	fn := call.StaticCallee()
+	if fn == nil {
+		return false
+	}
+	return isInstanceConstructorName(fn.Name())
+}
+
+// isInstanceConstructorName is the name check underlying
+// isInstanceConstructor, split out so it can be unit-tested without
+// building a real *ssa.Function.
+func isInstanceConstructorName(name string) bool {
+	switch name {
+	case "getInstance", "NewDB":
+		return true
+	default:
+		return false
+	}
+}
+
+// anyTainted reports whether any of vs is already marked tainted (direct
+// or copy).
+func anyTainted(vs []ssa.Value, tainted map[ssa.Value]taintKind) bool {
+	for _, v := range vs {
+		if tainted[v] != taintNone {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// Package getinstance is a minimal stand-in for gorm.io/gorm's *DB/Statement
+// shape, just enough surface area for AnalyzePackage to run its SSA walk
+// against a real getInstance()-shaped chain method in TestAnalyzePackage.
+package getinstance
+
+// Statement mirrors gorm.Statement enough to give Where something to
+// store through.
+type Statement struct {
+	Clause string
+}
+
+// DB mirrors gorm.DB: a clone field governing whether getInstance hands
+// back a fresh copy or the receiver itself, and a Statement pointer chain
+// methods mutate.
+type DB struct {
+	clone     int
+	Statement *Statement
+}
+
+// getInstance mirrors gorm.DB.getInstance: a fresh *DB when cloning, the
+// receiver itself otherwise.
+func (db *DB) getInstance() *DB {
+	if db.clone > 0 {
+		tx := &DB{Statement: &Statement{}}
+		return tx
+	}
+	return db
+}
+
+// Where mirrors the real gorm.DB.Where shape every chain method follows:
+// fork via getInstance, mutate the fork's Statement, return the fork. The
+// SSA walk should classify this ClassCopyOnWrite, not ClassMutating: the
+// store and the return both only trace back to db via the getInstance
+// call, never db's own memory directly.
+func (db *DB) Where(clause string) *DB {
+	tx := db.getInstance()
+	tx.Statement.Clause = clause
+	return tx
+}
+
+// BadMutate stores directly through the receiver's own Statement, with no
+// getInstance call in between, and returns the receiver itself - the
+// genuinely dangerous pattern the SSA walk should still classify
+// ClassMutating.
+func (db *DB) BadMutate(clause string) *DB {
+	db.Statement.Clause = clause
+	return db
+}
+
+// Count is unrelated to the receiver's memory entirely - ClassPure.
+func (db *DB) Count() int {
+	return len(db.Statement.Clause)
+}
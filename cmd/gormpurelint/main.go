@@ -0,0 +1,20 @@
+// Command gormpurelint runs analysis/gormpurelint as a standalone
+// go vet-style binary. It supports singlechecker's built-in `-fix` flag,
+// which applies gormpurelint's SuggestedFixes (inserting
+// .Session(&gorm.Session{NewDB: true}) at each flagged chain root), and
+// the analyzer's own `-enumeration` flag to widen the survey data baked in
+// at build time, e.g.:
+//
+//	go run ./scripts/methods > enumeration.json
+//	go run ./cmd/gormpurelint -enumeration=enumeration.json ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/mpyw/gorm-purity-survey/analysis/gormpurelint"
+)
+
+func main() {
+	singlechecker.Main(gormpurelint.Analyzer)
+}
@@ -0,0 +1,109 @@
+// Command purity-matrix drives the purity survey across a configured list
+// of GORM releases and merges the per-version results into one matrix.
+//
+// For each version it `go get`s gorm.io/gorm@version into a scratch copy
+// of this module, runs `go test -run TestSurvey -args -report=...`, and
+// folds the resulting report.Report into a report.Matrix. The matrix is
+// rendered as Markdown and HTML, and any method whose verdict flipped
+// between adjacent versions is written to regressions.json - the signal a
+// maintainer needs to decide whether a GORM bump requires a whitelist
+// update.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mpyw/gorm-purity-survey/internal/scratchmodule"
+	"github.com/mpyw/gorm-purity-survey/report"
+)
+
+func main() {
+	versionsFlag := flag.String("versions", "", "comma-separated list of gorm.io/gorm versions to survey, e.g. v1.24.0,v1.25.0,v1.26.0")
+	moduleDir := flag.String("module-dir", ".", "path to the gorm-purity-survey module to copy into each scratch workdir")
+	outMD := flag.String("out-md", "matrix.md", "path to write the Markdown matrix")
+	outHTML := flag.String("out-html", "matrix.html", "path to write the HTML matrix")
+	outRegressions := flag.String("out-regressions", "regressions.json", "path to write the regressions JSON")
+	flag.Parse()
+
+	if *versionsFlag == "" {
+		fmt.Fprintln(os.Stderr, "purity-matrix: -versions is required")
+		os.Exit(2)
+	}
+	versions := strings.Split(*versionsFlag, ",")
+
+	matrix := report.NewMatrix()
+
+	for _, version := range versions {
+		version = strings.TrimSpace(version)
+		if version == "" {
+			continue
+		}
+		r, err := surveyVersion(*moduleDir, version)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "purity-matrix: %s: %v\n", version, err)
+			continue
+		}
+		matrix.Add(r)
+	}
+
+	if err := scratchmodule.WriteFile(*outMD, matrix.WriteMarkdown); err != nil {
+		fmt.Fprintf(os.Stderr, "purity-matrix: writing markdown: %v\n", err)
+		os.Exit(1)
+	}
+	if err := scratchmodule.WriteFile(*outHTML, matrix.WriteHTML); err != nil {
+		fmt.Fprintf(os.Stderr, "purity-matrix: writing html: %v\n", err)
+		os.Exit(1)
+	}
+
+	regressions := matrix.Regressions()
+	data, err := json.MarshalIndent(regressions, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "purity-matrix: marshaling regressions: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*outRegressions, data, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "purity-matrix: writing regressions: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("purity-matrix: surveyed %d version(s), found %d regression(s)\n", len(versions), len(regressions))
+}
+
+// surveyVersion prepares a scratch copy of moduleDir pinned to version,
+// runs the survey, and loads the resulting report.Report.
+func surveyVersion(moduleDir, version string) (report.Report, error) {
+	scratch, cleanup, err := scratchmodule.Prepare(moduleDir, version, "purity-matrix-")
+	if err != nil {
+		return report.Report{}, err
+	}
+	defer cleanup()
+
+	reportPath := filepath.Join(scratch, "report.json")
+	cmd := exec.Command("go", "test", "./tests/...", "-run", "TestSurvey", "-args", "-report="+reportPath)
+	cmd.Dir = scratch
+	cmd.Env = append(os.Environ(), "GORM_VERSION="+version)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	// TestSurvey fails the build on any impure finding for the version
+	// under test; that's expected here, we still want the report.
+	_ = cmd.Run()
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("reading report for %s: %w", version, err)
+	}
+	var r report.Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return report.Report{}, fmt.Errorf("parsing report for %s: %w", version, err)
+	}
+	if r.GormVersion == "" {
+		r.GormVersion = version
+	}
+	return r, nil
+}
@@ -0,0 +1,72 @@
+// Command purity-report gates CI on GORM purity regressions.
+//
+// It reads a report.Report JSON file (produced by
+// `go test -run TestSurvey -args -report=out.json`) and, optionally, a
+// baseline report from a previous run. It exits non-zero if the current
+// report contains a finding that reaches -min-severity without the
+// baseline already having reached it (see report.Gate), so CI can fail a
+// pull request that introduces a new pollution bug - or one more severe
+// than what the baseline already tolerates - without anyone having to
+// read test logs.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mpyw/gorm-purity-survey/report"
+)
+
+func main() {
+	current := flag.String("current", "", "path to the current report JSON (required)")
+	baseline := flag.String("baseline", "", "path to a baseline report JSON to diff against")
+	minSeverity := flag.String("min-severity", string(report.SeverityUnknown), "minimum report.Severity to gate on (safe, unknown, leaks_clause, leaks_callbacks, leaks_schema)")
+	flag.Parse()
+
+	if *current == "" {
+		fmt.Fprintln(os.Stderr, "purity-report: -current is required")
+		os.Exit(2)
+	}
+
+	curReport, err := loadReport(*current)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "purity-report: %v\n", err)
+		os.Exit(2)
+	}
+
+	var baseReport report.Report
+	if *baseline != "" {
+		baseReport, err = loadReport(*baseline)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "purity-report: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	regressions := report.Gate(curReport, baseReport, report.Severity(*minSeverity))
+
+	fmt.Printf("gorm-purity-survey: %s, %d methods, %d impure, %d new regression(s) at >= severity %q\n",
+		curReport.GormVersion, len(curReport.Findings), len(curReport.ImpureFindings()), len(regressions), *minSeverity)
+
+	for _, r := range regressions {
+		fmt.Printf("  REGRESSION: %s (%s): %s\n", r.Method, r.Severity, r.Note)
+	}
+
+	if len(regressions) > 0 {
+		os.Exit(1)
+	}
+}
+
+func loadReport(path string) (report.Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var r report.Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return report.Report{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return r, nil
+}
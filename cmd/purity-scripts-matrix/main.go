@@ -0,0 +1,112 @@
+// Command purity-scripts-matrix drives scripts/purity (the testWhere,
+// testLimit, testSession, testBegin, ... binary) across a configured list
+// of GORM releases the same way cmd/purity-matrix drives TestSurvey, and
+// folds the resulting report.Report into a report.Matrix.
+//
+// For each version it `go get`s gorm.io/gorm@version into a scratch copy
+// of this module, runs `go run ./scripts/purity` with
+// GORM_PURITY_REPORT_FORMAT=report so the binary emits a report.Report
+// instead of its own ad hoc JSON, and folds it into the matrix. Beyond
+// the Verdict-flip regressions cmd/purity-matrix already surfaces, this
+// also prints report.Matrix.DimensionRegressions - the ReturnClone/
+// ImpureMode changes that don't flip Verdict on their own, like Begin's
+// ReturnClone moving from 2 to 1 between v1.23.1 and v1.23.2.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mpyw/gorm-purity-survey/internal/scratchmodule"
+	"github.com/mpyw/gorm-purity-survey/report"
+)
+
+func main() {
+	versionsFlag := flag.String("versions", "", "comma-separated list of gorm.io/gorm versions to survey, e.g. v1.20.0,v1.23.1,v1.23.2,v1.25.0,v1.26.0")
+	moduleDir := flag.String("module-dir", ".", "path to the gorm-purity-survey module to copy into each scratch workdir")
+	outMD := flag.String("out-md", "scripts-matrix.md", "path to write the Markdown matrix")
+	outRegressions := flag.String("out-regressions", "scripts-regressions.json", "path to write the regressions JSON")
+	outDimensions := flag.String("out-dimensions", "scripts-dimension-regressions.json", "path to write the ReturnClone/ImpureMode regressions JSON")
+	flag.Parse()
+
+	if *versionsFlag == "" {
+		fmt.Fprintln(os.Stderr, "purity-scripts-matrix: -versions is required")
+		os.Exit(2)
+	}
+	versions := strings.Split(*versionsFlag, ",")
+
+	matrix := report.NewMatrix()
+
+	for _, version := range versions {
+		version = strings.TrimSpace(version)
+		if version == "" {
+			continue
+		}
+		r, err := surveyVersion(*moduleDir, version)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "purity-scripts-matrix: %s: %v\n", version, err)
+			continue
+		}
+		matrix.Add(r)
+	}
+
+	if err := scratchmodule.WriteFile(*outMD, matrix.WriteMarkdown); err != nil {
+		fmt.Fprintf(os.Stderr, "purity-scripts-matrix: writing markdown: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeJSON(*outRegressions, matrix.Regressions()); err != nil {
+		fmt.Fprintf(os.Stderr, "purity-scripts-matrix: writing regressions: %v\n", err)
+		os.Exit(1)
+	}
+	dimRegressions := matrix.DimensionRegressions()
+	if err := writeJSON(*outDimensions, dimRegressions); err != nil {
+		fmt.Fprintf(os.Stderr, "purity-scripts-matrix: writing dimension regressions: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("purity-scripts-matrix: surveyed %d version(s), found %d regression(s), %d dimension change(s)\n",
+		len(versions), len(matrix.Regressions()), len(dimRegressions))
+}
+
+// surveyVersion prepares a scratch copy of moduleDir pinned to version,
+// runs scripts/purity with GORM_PURITY_REPORT_FORMAT=report, and loads
+// the resulting report.Report.
+func surveyVersion(moduleDir, version string) (report.Report, error) {
+	scratch, cleanup, err := scratchmodule.Prepare(moduleDir, version, "purity-scripts-matrix-")
+	if err != nil {
+		return report.Report{}, err
+	}
+	defer cleanup()
+
+	cmd := exec.Command("go", "run", "./scripts/purity")
+	cmd.Dir = scratch
+	cmd.Env = append(os.Environ(), "GORM_VERSION="+version, "GORM_PURITY_REPORT_FORMAT=report")
+	var stdout strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return report.Report{}, fmt.Errorf("go run ./scripts/purity for %s: %w", version, err)
+	}
+
+	var r report.Report
+	if err := json.Unmarshal([]byte(stdout.String()), &r); err != nil {
+		return report.Report{}, fmt.Errorf("parsing report for %s: %w", version, err)
+	}
+	if r.GormVersion == "" {
+		r.GormVersion = version
+	}
+	return r, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
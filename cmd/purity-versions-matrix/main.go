@@ -0,0 +1,142 @@
+// Command purity-versions-matrix drives TestVersionsMatrix (the versions/
+// package probes) across a configured list of GORM releases the same way
+// cmd/purity-matrix drives TestSurvey, but additionally selects the
+// gorm_vNNNplus build tags each release needs so version-gated methods
+// (CreateInBatches, ToSQL, Connection, InnerJoins, MapColumns, ...) are
+// only probed on builds where they exist.
+//
+// For each version it `go get`s gorm.io/gorm@version into a scratch copy
+// of this module, runs `go test -tags=<computed> -run TestVersionsMatrix`,
+// and folds the resulting report.Report into a report.Matrix keyed by
+// (method, version) - the matrix a maintainer needs to see which methods
+// changed purity semantics between releases.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mpyw/gorm-purity-survey/internal/scratchmodule"
+	"github.com/mpyw/gorm-purity-survey/report"
+)
+
+func main() {
+	versionsFlag := flag.String("versions", "", "comma-separated list of gorm.io/gorm versions to survey, e.g. v1.20.0,v1.21.0,v1.23.0,v1.25.0,v1.26.0")
+	moduleDir := flag.String("module-dir", ".", "path to the gorm-purity-survey module to copy into each scratch workdir")
+	outMD := flag.String("out-md", "versions-matrix.md", "path to write the Markdown matrix")
+	outRegressions := flag.String("out-regressions", "versions-regressions.json", "path to write the regressions JSON")
+	flag.Parse()
+
+	if *versionsFlag == "" {
+		fmt.Fprintln(os.Stderr, "purity-versions-matrix: -versions is required")
+		os.Exit(2)
+	}
+	versions := strings.Split(*versionsFlag, ",")
+
+	matrix := report.NewMatrix()
+
+	for _, version := range versions {
+		version = strings.TrimSpace(version)
+		if version == "" {
+			continue
+		}
+		r, err := surveyVersion(*moduleDir, version)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "purity-versions-matrix: %s: %v\n", version, err)
+			continue
+		}
+		matrix.Add(r)
+	}
+
+	if err := scratchmodule.WriteFile(*outMD, matrix.WriteMarkdown); err != nil {
+		fmt.Fprintf(os.Stderr, "purity-versions-matrix: writing markdown: %v\n", err)
+		os.Exit(1)
+	}
+
+	regressions := matrix.Regressions()
+	data, err := json.MarshalIndent(regressions, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "purity-versions-matrix: marshaling regressions: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*outRegressions, data, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "purity-versions-matrix: writing regressions: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("purity-versions-matrix: surveyed %d version(s), found %d regression(s)\n", len(versions), len(regressions))
+}
+
+// tagsForVersion derives the cumulative gorm_vNNNplus build tags a
+// "vMAJOR.MINOR.PATCH" version string needs, mirroring the minor-version
+// boundaries versions/ gates on.
+func tagsForVersion(version string) []string {
+	v := strings.TrimPrefix(version, "v")
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return nil
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil
+	}
+
+	var tags []string
+	if minor >= 21 {
+		tags = append(tags, "gorm_v121plus")
+	}
+	if minor >= 23 {
+		tags = append(tags, "gorm_v123plus")
+	}
+	if minor >= 25 {
+		tags = append(tags, "gorm_v125plus")
+	}
+	if minor >= 26 {
+		tags = append(tags, "gorm_v126plus")
+	}
+	return tags
+}
+
+// surveyVersion prepares a scratch copy of moduleDir pinned to version,
+// runs TestVersionsMatrix with the build tags that version supports, and
+// loads the resulting report.Report.
+func surveyVersion(moduleDir, version string) (report.Report, error) {
+	scratch, cleanup, err := scratchmodule.Prepare(moduleDir, version, "purity-versions-matrix-")
+	if err != nil {
+		return report.Report{}, err
+	}
+	defer cleanup()
+
+	reportPath := filepath.Join(scratch, "versions-report.json")
+	args := []string{"test", "./tests/...", "-run", "TestVersionsMatrix"}
+	if tags := tagsForVersion(version); len(tags) > 0 {
+		args = append(args, "-tags="+strings.Join(tags, ","))
+	}
+	args = append(args, "-args", "-versions-report="+reportPath)
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = scratch
+	cmd.Env = append(os.Environ(), "GORM_VERSION="+version)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	_ = cmd.Run()
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		return report.Report{}, fmt.Errorf("reading report for %s: %w", version, err)
+	}
+	var r report.Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return report.Report{}, fmt.Errorf("parsing report for %s: %w", version, err)
+	}
+	if r.GormVersion == "" {
+		r.GormVersion = version
+	}
+	return r, nil
+}
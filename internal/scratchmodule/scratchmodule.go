@@ -0,0 +1,94 @@
+// Package scratchmodule provides the scratch-workspace harness
+// cmd/purity-matrix, cmd/purity-versions-matrix, and cmd/purity-scripts-matrix
+// all need: copy this module into a throwaway directory, pin
+// gorm.io/gorm to a specific version there with `go get`, and run
+// commands against that copy so each version survey gets an isolated
+// go.mod/go.sum instead of mutating the caller's own module.
+package scratchmodule
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Prepare copies moduleDir into a fresh temp directory named with prefix
+// and version, pins gorm.io/gorm to version inside that copy via `go
+// get`, and returns the copy's path along with a cleanup func that
+// removes it. Callers should `defer cleanup()` immediately.
+func Prepare(moduleDir, version, prefix string) (dir string, cleanup func(), err error) {
+	scratch, err := os.MkdirTemp("", prefix+strings.ReplaceAll(version, "/", "_"))
+	if err != nil {
+		return "", nil, fmt.Errorf("creating scratch dir: %w", err)
+	}
+	cleanup = func() { os.RemoveAll(scratch) }
+
+	if err := CopyTree(moduleDir, scratch); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("copying module: %w", err)
+	}
+	if err := Run(scratch, "go", "get", "gorm.io/gorm@"+version); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("go get gorm.io/gorm@%s: %w", version, err)
+	}
+	return scratch, cleanup, nil
+}
+
+// Run runs name with args in dir, streaming both stdout and stderr to
+// this process's stderr.
+func Run(dir string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// WriteFile creates path and calls render with it, mirroring the
+// report.Matrix WriteMarkdown/WriteHTML signature.
+func WriteFile(path string, render func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return render(f)
+}
+
+// CopyTree copies the module tree into dst, skipping every path that
+// starts with ".git", so each version survey runs against an isolated
+// checkout and go.mod. Nothing else is excluded.
+func CopyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if strings.HasPrefix(rel, ".git") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
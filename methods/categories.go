@@ -25,6 +25,9 @@ const (
 
 	// CategoryInterfaceArg - Methods with interface{} args needing deep investigation
 	CategoryInterfaceArg Category = "interface-arg"
+
+	// CategoryGeneric - Generics API (gorm.G[T], PreloadBuilder, JoinBuilder), v1.30+
+	CategoryGeneric Category = "generic"
 )
 
 // Priority represents survey priority.
@@ -135,6 +138,42 @@ var Methods = []MethodInfo{
 	{Name: "ScanRows", Category: CategoryUtility, Priority: PriorityLow, ReturnsDB: false, Notes: "Scan sql.Rows"},
 	{Name: "Explain", Category: CategoryUtility, Priority: PriorityLow, ReturnsDB: false, Notes: "Query explain"},
 
+	// === Generics API (gorm.G[T] / PreloadBuilder / JoinBuilder, v1.30+) ===
+	// gorm.G[T] is a value type wrapping an internal *gorm.DB. The open
+	// question for every entry below is whether that wrapping actually
+	// gives value semantics, or whether the internal *gorm.DB is still
+	// shared across copies of G[T] the way the pre-generics chain is.
+	{Name: "G[T].Where", Category: CategoryGeneric, Priority: PriorityHigh, ReturnsDB: true, HasInterfaceArg: true, Notes: "Generics chain builder"},
+	{Name: "G[T].Order", Category: CategoryGeneric, Priority: PriorityHigh, ReturnsDB: true, HasInterfaceArg: true, Notes: "Generics chain builder"},
+	{Name: "G[T].Preload", Category: CategoryGeneric, Priority: PriorityHigh, ReturnsDB: true, HasInterfaceArg: true, TakesDBCallback: true, Notes: "Generics chain builder, may take PreloadBuilder callback"},
+	{Name: "G[T].Joins", Category: CategoryGeneric, Priority: PriorityHigh, ReturnsDB: true, HasInterfaceArg: true, TakesDBCallback: true, Notes: "Generics chain builder, may take JoinBuilder callback"},
+	{Name: "G[T].Raw", Category: CategoryGeneric, Priority: PriorityMedium, ReturnsDB: true, HasInterfaceArg: true, Notes: "Generics raw SQL"},
+	{Name: "G[T].Create", Category: CategoryGeneric, Priority: PriorityMedium, ReturnsDB: false, HasInterfaceArg: true, Notes: "Generics finisher"},
+	{Name: "G[T].Update", Category: CategoryGeneric, Priority: PriorityMedium, ReturnsDB: false, HasInterfaceArg: true, Notes: "Generics finisher"},
+	{Name: "G[T].Delete", Category: CategoryGeneric, Priority: PriorityMedium, ReturnsDB: false, Notes: "Generics finisher"},
+	{Name: "G[T].First", Category: CategoryGeneric, Priority: PriorityHigh, ReturnsDB: false, Notes: "Generics finisher"},
+	{Name: "G[T].Find", Category: CategoryGeneric, Priority: PriorityHigh, ReturnsDB: false, Notes: "Generics finisher"},
+	{Name: "G[T].Take", Category: CategoryGeneric, Priority: PriorityMedium, ReturnsDB: false, Notes: "Generics finisher"},
+	{Name: "G[T].Count", Category: CategoryGeneric, Priority: PriorityMedium, ReturnsDB: false, Notes: "Generics finisher"},
+	{Name: "G[T].Scan", Category: CategoryGeneric, Priority: PriorityMedium, ReturnsDB: false, HasInterfaceArg: true, Notes: "Generics finisher"},
+	{Name: "G[T].Exec", Category: CategoryGeneric, Priority: PriorityMedium, ReturnsDB: false, Notes: "Generics finisher"},
+
+	{Name: "PreloadBuilder.Where", Category: CategoryGeneric, Priority: PriorityHigh, HasInterfaceArg: true, Notes: "Preload callback builder"},
+	{Name: "PreloadBuilder.Order", Category: CategoryGeneric, Priority: PriorityMedium, HasInterfaceArg: true, Notes: "Preload callback builder"},
+	{Name: "PreloadBuilder.Limit", Category: CategoryGeneric, Priority: PriorityMedium, Notes: "Preload callback builder"},
+	{Name: "PreloadBuilder.Offset", Category: CategoryGeneric, Priority: PriorityMedium, Notes: "Preload callback builder"},
+	{Name: "PreloadBuilder.Preload", Category: CategoryGeneric, Priority: PriorityMedium, HasInterfaceArg: true, Notes: "Nested preload"},
+	{Name: "PreloadBuilder.Select", Category: CategoryGeneric, Priority: PriorityMedium, HasInterfaceArg: true, Notes: "Preload callback builder"},
+	{Name: "PreloadBuilder.Omit", Category: CategoryGeneric, Priority: PriorityMedium, Notes: "Preload callback builder"},
+	{Name: "PreloadBuilder.Args", Category: CategoryGeneric, Priority: PriorityLow, HasInterfaceArg: true, Notes: "Association lookup args"},
+
+	{Name: "JoinBuilder.Where", Category: CategoryGeneric, Priority: PriorityHigh, HasInterfaceArg: true, Notes: "Join callback builder"},
+	{Name: "JoinBuilder.Order", Category: CategoryGeneric, Priority: PriorityMedium, HasInterfaceArg: true, Notes: "Join callback builder"},
+	{Name: "JoinBuilder.Select", Category: CategoryGeneric, Priority: PriorityMedium, HasInterfaceArg: true, Notes: "Join callback builder"},
+	{Name: "JoinBuilder.Omit", Category: CategoryGeneric, Priority: PriorityMedium, Notes: "Join callback builder"},
+	{Name: "JoinBuilder.Filter", Category: CategoryGeneric, Priority: PriorityMedium, HasInterfaceArg: true, Notes: "Join callback builder"},
+	{Name: "JoinBuilder.On", Category: CategoryGeneric, Priority: PriorityHigh, HasInterfaceArg: true, Notes: "Join ON clause builder"},
+
 	// === Dialect/Plugin Interface Methods (skip) ===
 	{Name: "Name", Category: CategoryUtility, Priority: PriorityLow, ReturnsDB: false, Notes: "Dialect name"},
 	{Name: "Apply", Category: CategoryUtility, Priority: PriorityLow, ReturnsDB: false, Notes: "Config apply"},
@@ -209,3 +248,15 @@ func FinisherMethods() []MethodInfo {
 	}
 	return result
 }
+
+// GenericMethods returns the Generics API (gorm.G[T], PreloadBuilder,
+// JoinBuilder) methods, available from GORM v1.30+.
+func GenericMethods() []MethodInfo {
+	var result []MethodInfo
+	for _, m := range Methods {
+		if m.Category == CategoryGeneric {
+			result = append(result, m)
+		}
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+package report
+
+// Severity classifies what a purity finding's leak actually touches,
+// ordered from least to most consequential so a CI gate can threshold on
+// it instead of treating every impure finding as equally urgent.
+type Severity string
+
+const (
+	// SeveritySafe means the finding didn't leak anything.
+	SeveritySafe Severity = "safe"
+	// SeverityUnknown means the dimension wasn't testable, so severity
+	// can't be determined either way.
+	SeverityUnknown Severity = "unknown"
+	// SeverityLeaksClause means a builder-method leak stayed within a
+	// single query's shape - a WHERE/JOIN/GROUP/ORDER clause polluting a
+	// receiver or sibling branch, still scoped to one query.
+	SeverityLeaksClause Severity = "leaks_clause"
+	// SeverityLeaksCallbacks means a callback argument (Scopes, Preload,
+	// Transaction, Connection, ...) shared a *gorm.Statement with a
+	// caller instead of getting an isolated one - a leak that can cross
+	// unrelated call sites, not just sibling branches of one query.
+	SeverityLeaksCallbacks Severity = "leaks_callbacks"
+	// SeverityLeaksSchema means the leak changed which columns/schema a
+	// query touches (Select/Omit/Distinct/MapColumns), the shape most
+	// likely to surface as a silent data-exposure bug rather than a
+	// merely redundant WHERE condition.
+	SeverityLeaksSchema Severity = "leaks_schema"
+)
+
+// severityRank orders Severity from least to most consequential.
+// Unrecognized values (including the zero value "") rank below
+// SeveritySafe, so an old report with no Severity data never outranks a
+// real threshold.
+var severityRank = map[Severity]int{
+	SeveritySafe:           1,
+	SeverityUnknown:        2,
+	SeverityLeaksClause:    3,
+	SeverityLeaksCallbacks: 4,
+	SeverityLeaksSchema:    5,
+}
+
+// AtLeast reports whether s is at least as severe as min, per severityRank.
+func (s Severity) AtLeast(min Severity) bool {
+	return severityRank[s] >= severityRank[min]
+}
+
+// GateRegression describes a method whose current finding reached
+// minSeverity without the baseline already having reached it - the thing
+// a CI pipeline should fail a pull request on.
+type GateRegression struct {
+	Method   string
+	Severity Severity
+	Note     string
+}
+
+// Gate compares cur against baseline and returns every method whose
+// current finding's Severity is at least minSeverity while its baseline
+// finding (if any) fell short of it. A method baseline already accepted
+// at that severity is not reported again, so a known, intentionally
+// tolerated leak doesn't re-fail CI on every run.
+func Gate(cur, baseline Report, minSeverity Severity) []GateRegression {
+	baseSeverity := make(map[string]Severity, len(baseline.Findings))
+	for _, f := range baseline.Findings {
+		baseSeverity[f.Method] = f.Severity
+	}
+
+	var out []GateRegression
+	for _, f := range cur.Findings {
+		if !f.Severity.AtLeast(minSeverity) {
+			continue
+		}
+		if baseSeverity[f.Method].AtLeast(minSeverity) {
+			continue
+		}
+		out = append(out, GateRegression{Method: f.Method, Severity: f.Severity, Note: f.Note})
+	}
+	return out
+}
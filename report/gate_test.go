@@ -0,0 +1,34 @@
+package report
+
+import "testing"
+
+func TestSeverity_AtLeast(t *testing.T) {
+	if !SeverityLeaksSchema.AtLeast(SeverityLeaksClause) {
+		t.Errorf("LeaksSchema should rank at least LeaksClause")
+	}
+	if SeverityLeaksClause.AtLeast(SeverityLeaksCallbacks) {
+		t.Errorf("LeaksClause should not rank at least LeaksCallbacks")
+	}
+	if Severity("").AtLeast(SeveritySafe) {
+		t.Errorf("an unset Severity should not outrank Safe")
+	}
+}
+
+func TestGate(t *testing.T) {
+	baseline := Report{Findings: []PurityFinding{
+		{Method: "Where", Severity: SeverityLeaksClause},
+	}}
+	current := Report{Findings: []PurityFinding{
+		{Method: "Where", Severity: SeverityLeaksClause, Note: "already known"},
+		{Method: "Select", Severity: SeverityLeaksSchema, Note: "new leak"},
+		{Method: "Session", Severity: SeveritySafe},
+	}}
+
+	regressions := Gate(current, baseline, SeverityLeaksClause)
+	if len(regressions) != 1 {
+		t.Fatalf("len(Gate(...)) = %d, want 1", len(regressions))
+	}
+	if regressions[0].Method != "Select" {
+		t.Errorf("regression Method = %q, want Select", regressions[0].Method)
+	}
+}
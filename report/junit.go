@@ -0,0 +1,73 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitTestSuites mirrors the subset of the JUnit XML schema CI systems
+// (GitHub Actions, GitLab, Jenkins) actually parse.
+type junitTestSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// WriteJUnit renders the report as a JUnit XML document, one testcase per
+// finding and one failure per impure verdict, so CI dashboards that already
+// understand JUnit can surface purity regressions alongside regular tests.
+func (r Report) WriteJUnit(w io.Writer) error {
+	suite := junitSuite{
+		Name:  fmt.Sprintf("gorm-purity-survey (%s)", r.GormVersion),
+		Tests: len(r.Findings),
+	}
+
+	for _, f := range r.Findings {
+		tc := junitTestCase{
+			Name:      f.Method,
+			ClassName: f.Category,
+		}
+		if f.Verdict == VerdictImpure {
+			suite.Failures++
+			msg := f.Note
+			if msg == "" {
+				msg = fmt.Sprintf("%s is impure", f.Method)
+			}
+			body := msg
+			if f.SQLAfter != "" {
+				body = fmt.Sprintf("%s\n\ncaptured SQL: %s", msg, f.SQLAfter)
+			} else if f.SQLBefore != "" {
+				body = fmt.Sprintf("%s\n\ncaptured SQL: %s", msg, f.SQLBefore)
+			}
+			tc.Failure = &junitFailure{Message: msg, Body: body}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	doc := junitTestSuites{Suites: []junitSuite{suite}}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
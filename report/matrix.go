@@ -0,0 +1,240 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+)
+
+// Matrix merges per-version Report runs into a two-dimensional
+// map[Method]map[Version]Verdict, so a maintainer can see in one place
+// which methods changed purity between GORM releases instead of diffing
+// individual JSON reports by hand.
+type Matrix struct {
+	versions []string
+	methods  []string
+	cells    map[string]map[string]Verdict
+	notes    map[string]map[string]string
+
+	// returnClones and impureModes track the two dimensions whose
+	// flips don't always show up as a Verdict change (e.g. Begin's
+	// ReturnClone went 2 -> 1 between v1.23.1 and v1.23.2 while it
+	// stayed ImmutableReturn=true the whole time) - see
+	// DimensionRegressions.
+	returnClones map[string]map[string]*int
+	impureModes  map[string]map[string]*string
+}
+
+// NewMatrix creates an empty Matrix.
+func NewMatrix() *Matrix {
+	return &Matrix{
+		cells:        make(map[string]map[string]Verdict),
+		notes:        make(map[string]map[string]string),
+		returnClones: make(map[string]map[string]*int),
+		impureModes:  make(map[string]map[string]*string),
+	}
+}
+
+// Add merges one version's Report into the matrix. Versions are kept in
+// the order they were first added; methods are kept sorted.
+func (m *Matrix) Add(r Report) {
+	versionSeen := false
+	for _, v := range m.versions {
+		if v == r.GormVersion {
+			versionSeen = true
+			break
+		}
+	}
+	if !versionSeen {
+		m.versions = append(m.versions, r.GormVersion)
+	}
+
+	for _, f := range r.Findings {
+		if _, ok := m.cells[f.Method]; !ok {
+			m.cells[f.Method] = make(map[string]Verdict)
+			m.notes[f.Method] = make(map[string]string)
+			m.returnClones[f.Method] = make(map[string]*int)
+			m.impureModes[f.Method] = make(map[string]*string)
+			m.methods = append(m.methods, f.Method)
+		}
+		m.cells[f.Method][r.GormVersion] = f.Verdict
+		m.notes[f.Method][r.GormVersion] = f.Note
+		m.returnClones[f.Method][r.GormVersion] = f.ReturnClone
+		m.impureModes[f.Method][r.GormVersion] = f.ImpureMode
+	}
+
+	sort.Strings(m.methods)
+}
+
+// Verdict returns the recorded verdict for method at version, or
+// VerdictUnknown if no finding was recorded.
+func (m *Matrix) Verdict(method, version string) Verdict {
+	if byVersion, ok := m.cells[method]; ok {
+		if v, ok := byVersion[version]; ok {
+			return v
+		}
+	}
+	return VerdictUnknown
+}
+
+// Regression describes a method whose verdict flipped between two
+// adjacent versions, in the order versions were added to the matrix.
+type Regression struct {
+	Method      string
+	FromVersion string
+	FromVerdict Verdict
+	ToVersion   string
+	ToVerdict   Verdict
+}
+
+// Regressions walks every method across adjacent version pairs (in
+// insertion order) and reports every verdict flip. This is the single
+// most valuable output for a maintainer deciding whether a GORM bump
+// needs a whitelist update: a flip from pure to impure is a new
+// regression; a flip from impure to pure is a fix worth relaxing a
+// workaround for.
+func (m *Matrix) Regressions() []Regression {
+	var out []Regression
+	for _, method := range m.methods {
+		for i := 1; i < len(m.versions); i++ {
+			from, to := m.versions[i-1], m.versions[i]
+			fromVerdict := m.Verdict(method, from)
+			toVerdict := m.Verdict(method, to)
+			if fromVerdict == VerdictUnknown || toVerdict == VerdictUnknown {
+				continue
+			}
+			if fromVerdict != toVerdict {
+				out = append(out, Regression{
+					Method:      method,
+					FromVersion: from,
+					FromVerdict: fromVerdict,
+					ToVersion:   to,
+					ToVerdict:   toVerdict,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// DimensionRegression describes a method whose ReturnClone or ImpureMode
+// value changed between two adjacent versions, independent of whether its
+// overall Verdict flipped.
+type DimensionRegression struct {
+	Method      string
+	Dimension   string // "return_clone" or "impure_mode"
+	FromVersion string
+	From        string
+	ToVersion   string
+	To          string
+}
+
+// DimensionRegressions walks every method across adjacent version pairs
+// and reports every ReturnClone/ImpureMode change, even when Verdict
+// stayed the same - e.g. Begin's ReturnClone moved from 2 to 1 between
+// v1.23.1 and v1.23.2 while remaining ImmutableReturn=true throughout.
+func (m *Matrix) DimensionRegressions() []DimensionRegression {
+	var out []DimensionRegression
+	for _, method := range m.methods {
+		for i := 1; i < len(m.versions); i++ {
+			from, to := m.versions[i-1], m.versions[i]
+
+			fromClone, fromOK := m.returnClones[method][from]
+			toClone, toOK := m.returnClones[method][to]
+			if fromOK && toOK && fromClone != nil && toClone != nil && *fromClone != *toClone {
+				out = append(out, DimensionRegression{
+					Method: method, Dimension: "return_clone",
+					FromVersion: from, From: fmt.Sprintf("%d", *fromClone),
+					ToVersion: to, To: fmt.Sprintf("%d", *toClone),
+				})
+			}
+
+			fromMode, fromOK := m.impureModes[method][from]
+			toMode, toOK := m.impureModes[method][to]
+			if fromOK && toOK && fromMode != nil && toMode != nil && *fromMode != *toMode {
+				out = append(out, DimensionRegression{
+					Method: method, Dimension: "impure_mode",
+					FromVersion: from, From: *fromMode,
+					ToVersion: to, To: *toMode,
+				})
+			}
+		}
+	}
+	return out
+}
+
+// WriteMarkdown renders the matrix as a GitHub-flavored Markdown table.
+func (m *Matrix) WriteMarkdown(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "| Method |"); err != nil {
+		return err
+	}
+	for _, v := range m.versions {
+		if _, err := fmt.Fprintf(w, " %s |", v); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "|---|"); err != nil {
+		return err
+	}
+	for range m.versions {
+		if _, err := fmt.Fprintf(w, "---|"); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	for _, method := range m.methods {
+		if _, err := fmt.Fprintf(w, "| %s |", method); err != nil {
+			return err
+		}
+		for _, v := range m.versions {
+			if _, err := fmt.Fprintf(w, " %s |", m.Verdict(method, v)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteHTML renders the matrix as a plain HTML table, one <tr> per method.
+func (m *Matrix) WriteHTML(w io.Writer) error {
+	if _, err := io.WriteString(w, "<table>\n<thead><tr><th>Method</th>"); err != nil {
+		return err
+	}
+	for _, v := range m.versions {
+		if _, err := fmt.Fprintf(w, "<th>%s</th>", html.EscapeString(v)); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, "</tr></thead>\n<tbody>\n"); err != nil {
+		return err
+	}
+
+	for _, method := range m.methods {
+		if _, err := fmt.Fprintf(w, "<tr><td>%s</td>", html.EscapeString(method)); err != nil {
+			return err
+		}
+		for _, v := range m.versions {
+			verdict := m.Verdict(method, v)
+			class := string(verdict)
+			if _, err := fmt.Fprintf(w, "<td class=%q>%s</td>", class, html.EscapeString(string(verdict))); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, "</tr>\n"); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, "</tbody>\n</table>\n")
+	return err
+}
@@ -0,0 +1,40 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatrix_Regressions(t *testing.T) {
+	m := NewMatrix()
+	m.Add(Report{GormVersion: "v1.24.0", Findings: []PurityFinding{
+		{Method: "Where", Category: "chain", Verdict: VerdictPure},
+	}})
+	m.Add(Report{GormVersion: "v1.25.0", Findings: []PurityFinding{
+		{Method: "Where", Category: "chain", Verdict: VerdictImpure, Note: "regressed"},
+	}})
+
+	regressions := m.Regressions()
+	if len(regressions) != 1 {
+		t.Fatalf("len(Regressions()) = %d, want 1", len(regressions))
+	}
+	r := regressions[0]
+	if r.Method != "Where" || r.FromVerdict != VerdictPure || r.ToVerdict != VerdictImpure {
+		t.Errorf("unexpected regression: %+v", r)
+	}
+}
+
+func TestMatrix_WriteMarkdown(t *testing.T) {
+	m := NewMatrix()
+	m.Add(Report{GormVersion: "v1.24.0", Findings: []PurityFinding{
+		{Method: "Where", Category: "chain", Verdict: VerdictPure},
+	}})
+
+	var buf strings.Builder
+	if err := m.WriteMarkdown(&buf); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	if !strings.Contains(buf.String(), "| Where |") {
+		t.Errorf("expected method row, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,117 @@
+// Package report defines a machine-readable format for GORM purity survey
+// results and serializes it to JSON, JUnit XML, and SARIF so CI systems and
+// downstream tooling can consume findings without scraping t.Log output.
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Verdict classifies the outcome of a single purity check.
+type Verdict string
+
+const (
+	// VerdictPure means the method did not pollute its receiver or leak
+	// state across calls.
+	VerdictPure Verdict = "pure"
+	// VerdictImpure means pollution or a callback/parent isolation
+	// violation was observed.
+	VerdictImpure Verdict = "impure"
+	// VerdictUnknown means the dimension was not testable (e.g. the
+	// method doesn't exist in this GORM version, or the probe panicked).
+	VerdictUnknown Verdict = "unknown"
+)
+
+// PurityFinding records the purity verdict for a single *gorm.DB method.
+// Fields are pointers where the dimension may not apply to every method
+// (e.g. CallbackIsolated only makes sense for methods taking a callback).
+type PurityFinding struct {
+	Method           string  `json:"method"`
+	Category         string  `json:"category"`
+	GormVersion      string  `json:"gorm_version"`
+	CallbackIsolated *bool   `json:"callback_isolated,omitempty"`
+	ParentIsolated   *bool   `json:"parent_isolated,omitempty"`
+	SameInstance     *bool   `json:"same_instance,omitempty"`
+	Clone            *int    `json:"clone,omitempty"` // clone value of the *gorm.DB handed to a callback (0=no clone, 1=stmt clone, 2=full clone)
+	SQLBefore        string  `json:"sql_before,omitempty"`
+	SQLAfter         string  `json:"sql_after,omitempty"`
+	Verdict          Verdict `json:"verdict"`
+	Note             string  `json:"note,omitempty"`
+
+	// ImpureMode, ReturnClone, and FinisherPreservesJoins carry the same
+	// dimensions scripts/purity's MethodResult tracks (see
+	// scripts/purity/report_emit.go), so a scripts/purity run can be
+	// converted to a Report without losing detail. Clone above doubles
+	// as scripts/purity's CallbackClone (the callback-argument clone
+	// value); ReturnClone is the method's own return value's clone.
+	ImpureMode             *string `json:"impure_mode,omitempty"`              // "accumulate" or "overwrite"
+	ReturnClone            *int    `json:"return_clone,omitempty"`             // clone value of the method's own returned *gorm.DB
+	FinisherPreservesJoins *bool   `json:"finisher_preserves_joins,omitempty"` // for Count: are Joins preserved after execution?
+
+	// Severity classifies what this finding's leak actually touches (see
+	// gate.go), independent of Verdict: two VerdictImpure findings can
+	// warrant very different urgency depending on whether a WHERE clause
+	// leaked or a callback's Statement was shared outright.
+	Severity Severity `json:"severity,omitempty"`
+}
+
+// Report is a full survey run: every finding collected for one GORM version.
+type Report struct {
+	GormVersion string          `json:"gorm_version"`
+	Driver      string          `json:"driver,omitempty"` // dialect name, e.g. "postgres" (see scripts/purity/dialects.go)
+	Findings    []PurityFinding `json:"findings"`
+}
+
+// Recorder collects findings during a test run. It is safe for concurrent
+// use so table-driven subtests can record from t.Run closures run with
+// t.Parallel.
+type Recorder struct {
+	mu          sync.Mutex
+	gormVersion string
+	findings    []PurityFinding
+}
+
+// NewRecorder creates a Recorder for the given GORM version string.
+func NewRecorder(gormVersion string) *Recorder {
+	return &Recorder{gormVersion: gormVersion}
+}
+
+// Record appends a finding to the run. GormVersion is filled in from the
+// Recorder if the caller left it empty.
+func (r *Recorder) Record(f PurityFinding) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f.GormVersion == "" {
+		f.GormVersion = r.gormVersion
+	}
+	r.findings = append(r.findings, f)
+}
+
+// Report snapshots everything recorded so far.
+func (r *Recorder) Report() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	findings := make([]PurityFinding, len(r.findings))
+	copy(findings, r.findings)
+	return Report{GormVersion: r.gormVersion, Findings: findings}
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// ImpureFindings returns the subset of findings whose verdict is impure.
+func (r Report) ImpureFindings() []PurityFinding {
+	var out []PurityFinding
+	for _, f := range r.Findings {
+		if f.Verdict == VerdictImpure {
+			out = append(out, f)
+		}
+	}
+	return out
+}
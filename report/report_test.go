@@ -0,0 +1,82 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestRecorder_Report(t *testing.T) {
+	rec := NewRecorder("v1.25.0")
+	rec.Record(PurityFinding{Method: "Where", Category: "chain", Verdict: VerdictPure})
+	rec.Record(PurityFinding{Method: "Session", Category: "immutable-return", Verdict: VerdictImpure, Note: "should never happen"})
+
+	r := rec.Report()
+	if r.GormVersion != "v1.25.0" {
+		t.Errorf("GormVersion = %q, want v1.25.0", r.GormVersion)
+	}
+	if len(r.Findings) != 2 {
+		t.Fatalf("len(Findings) = %d, want 2", len(r.Findings))
+	}
+	if got := r.ImpureFindings(); len(got) != 1 || got[0].Method != "Session" {
+		t.Errorf("ImpureFindings() = %+v, want [Session]", got)
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	r := Report{
+		GormVersion: "v1.25.0",
+		Findings: []PurityFinding{
+			{Method: "Where", Category: "chain", Verdict: VerdictPure, CallbackIsolated: boolPtr(true)},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"method": "Where"`) {
+		t.Errorf("JSON output missing method field: %s", buf.String())
+	}
+}
+
+func TestReport_WriteJUnit(t *testing.T) {
+	r := Report{
+		GormVersion: "v1.25.0",
+		Findings: []PurityFinding{
+			{Method: "Where", Category: "chain", Verdict: VerdictPure},
+			{Method: "Session", Category: "immutable-return", Verdict: VerdictImpure, Note: "regression"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteJUnit(&buf); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `failures="1"`) {
+		t.Errorf("expected exactly one failure, got: %s", out)
+	}
+	if !strings.Contains(out, "regression") {
+		t.Errorf("expected failure message in output, got: %s", out)
+	}
+}
+
+func TestReport_WriteSARIF(t *testing.T) {
+	r := Report{
+		GormVersion: "v1.25.0",
+		Findings: []PurityFinding{
+			{Method: "Session", Category: "immutable-return", Verdict: VerdictImpure, Note: "regression"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteSARIF(&buf); err != nil {
+		t.Fatalf("WriteSARIF: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"ruleId": "gormpurity/immutable-return"`) {
+		t.Errorf("expected rule id in SARIF output, got: %s", buf.String())
+	}
+}
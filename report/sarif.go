@@ -0,0 +1,127 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// The following types implement the subset of SARIF 2.1.0
+// (https://docs.oasis-open.org/sarif/sarif/v2.1.0/) that GitHub code
+// scanning and other SARIF viewers render: one run per GORM version, one
+// rule per method category, one result per impure finding.
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string                 `json:"id"`
+	ShortDescription sarifText              `json:"shortDescription"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// WriteSARIF renders the report as a SARIF 2.1.0 log. Only impure findings
+// become results; pure findings only contribute their rule definition so
+// the category still shows up in the rule catalog.
+func (r Report) WriteSARIF(w io.Writer) error {
+	rulesSeen := make(map[string]bool)
+	var rules []sarifRule
+	var results []sarifResult
+
+	for _, f := range r.Findings {
+		ruleID := "gormpurity/" + f.Category
+		if !rulesSeen[ruleID] {
+			rulesSeen[ruleID] = true
+			rules = append(rules, sarifRule{
+				ID:               ruleID,
+				ShortDescription: sarifText{Text: fmt.Sprintf("Purity findings for %s methods", f.Category)},
+			})
+		}
+
+		if f.Verdict != VerdictImpure {
+			continue
+		}
+
+		msg := f.Note
+		if msg == "" {
+			msg = fmt.Sprintf("%s (%s) is impure on GORM %s", f.Method, f.Category, f.GormVersion)
+		}
+
+		results = append(results, sarifResult{
+			RuleID:  ruleID,
+			Level:   "warning",
+			Message: sarifText{Text: msg},
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: "methods/categories.go"},
+					},
+				},
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "gorm-purity-survey",
+						InformationURI: "https://github.com/mpyw/gorm-purity-survey",
+						Version:        r.GormVersion,
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,189 @@
+// Package main runs a concurrency-pollution survey for GORM chain methods.
+//
+// The purity survey in scripts/purity compares a Statement before and after
+// a single sequential mutation. That misses the case this package targets:
+// a *gorm.DB handle shared across goroutines, the way an application struct
+// or a plugin like go-gorm/caches' easer might hold one connection and
+// dispatch concurrent requests through it. If a method mutates its receiver
+// (see the survey in scripts/purity), concurrent callers racing on the
+// same Statement.Clauses/Selects/Joins maps produce nondeterministic SQL -
+// or a `go test -race` failure - instead of a clean error.
+//
+// For each method under test, N goroutines share one "base" *gorm.DB, each
+// applies the method with its own marker and runs a Finisher against
+// sqlmock, and the result records whether every goroutine's captured SQL
+// contained only its own marker.
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+
+	"github.com/mpyw/gorm-purity-survey/tests/capture"
+)
+
+// goroutineCount is how many goroutines race against the shared base DB for
+// each method under test.
+const goroutineCount = 8
+
+// MockDialector is a minimal dialector for sqlmock testing (PostgreSQL style).
+type MockDialector struct {
+	Conn *sql.DB
+}
+
+func (d MockDialector) Name() string { return "postgres" }
+func (d MockDialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = d.Conn
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+func (d MockDialector) Migrator(db *gorm.DB) gorm.Migrator    { return migrator.Migrator{} }
+func (d MockDialector) DataTypeOf(field *schema.Field) string { return "TEXT" }
+func (d MockDialector) DefaultValueOf(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "NULL"}
+}
+func (d MockDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteByte('?')
+}
+func (d MockDialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteByte('"')
+	writer.WriteString(str)
+	writer.WriteByte('"')
+}
+func (d MockDialector) Explain(sql string, vars ...interface{}) string { return sql }
+
+// User is a test model.
+type User struct {
+	ID   uint
+	Name string
+	Role string
+}
+
+// branch applies the method under test to base with a per-goroutine marker
+// column and returns the *gorm.DB to run a Finisher on.
+type branch func(base *gorm.DB, marker string) *gorm.DB
+
+// probe is one method under concurrency test.
+type probe struct {
+	Name   string
+	Branch branch
+}
+
+// probes lists the chain methods the request calls out by name
+// (stmt.Clauses/Selects/Joins) plus the other everyday chain methods that
+// share the same Statement map-mutation shape.
+var probes = []probe{
+	{"Where", func(base *gorm.DB, marker string) *gorm.DB { return base.Where(marker+" = ?", true) }},
+	{"Clauses", func(base *gorm.DB, marker string) *gorm.DB {
+		return base.Clauses(clause.Expr{SQL: marker})
+	}},
+	{"Joins", func(base *gorm.DB, marker string) *gorm.DB { return base.Joins(marker) }},
+	{"Select", func(base *gorm.DB, marker string) *gorm.DB { return base.Select(marker) }},
+	{"Group", func(base *gorm.DB, marker string) *gorm.DB { return base.Group(marker) }},
+	{"Order", func(base *gorm.DB, marker string) *gorm.DB { return base.Order(marker) }},
+}
+
+// result holds the concurrency-pollution verdict for one method.
+type result struct {
+	Name      string
+	CrossTalk bool
+	Note      string
+}
+
+func main() {
+	fmt.Println("=== CONCURRENCY-POLLUTION SURVEY (shared *gorm.DB across goroutines) ===")
+	fmt.Printf("goroutines per method: %d (run this binary under `go test -race` equivalents in tests/concurrency_test.go for race detection)\n\n", goroutineCount)
+	fmt.Println("Method   | Concurrency-Safe | Note")
+	fmt.Println("---------|------------------|-----")
+
+	for _, p := range probes {
+		r := runProbe(p)
+		status := "✅ safe"
+		if r.CrossTalk {
+			status = "🚨 cross-talk"
+		}
+		fmt.Printf("%-8s | %-16s | %s\n", p.Name, status, r.Note)
+	}
+}
+
+// runProbe shares one base *gorm.DB across goroutineCount goroutines, each
+// applying p.Branch with a distinct marker and running a Finisher, then
+// checks whether any captured SQL contains more than one goroutine's marker
+// or is missing its own - the signature of receiver pollution racing across
+// goroutines.
+func runProbe(p probe) result {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		return result{Name: p.Name, CrossTalk: true, Note: fmt.Sprintf("setup error: %v", err)}
+	}
+	mock.MatchExpectationsInOrder(false)
+
+	cap := capture.New()
+	db, err := gorm.Open(MockDialector{Conn: mockDB}, &gorm.Config{Logger: cap.LogMode(4)})
+	if err != nil {
+		return result{Name: p.Name, CrossTalk: true, Note: fmt.Sprintf("open error: %v", err)}
+	}
+
+	for i := 0; i < goroutineCount; i++ {
+		mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	}
+
+	base := db.Model(&User{}).Where("base_cond = ?", true)
+
+	markers := make([]string, goroutineCount)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutineCount; i++ {
+		markers[i] = fmt.Sprintf("goroutine_%d_marker_col", i)
+		wg.Add(1)
+		go func(marker string) {
+			defer wg.Done()
+			var users []User
+			p.Branch(base, marker).Find(&users)
+		}(markers[i])
+	}
+	wg.Wait()
+
+	return evaluate(p.Name, markers, cap.AllSQL())
+}
+
+// evaluate checks that each marker appears in exactly one captured SQL
+// statement and that no captured statement contains more than one marker.
+func evaluate(name string, markers, sqls []string) result {
+	counts := make(map[string]int, len(markers))
+	for _, sql := range sqls {
+		normalized := strings.ToLower(sql)
+		present := 0
+		for _, m := range markers {
+			if strings.Contains(normalized, strings.ToLower(m)) {
+				counts[m]++
+				present++
+			}
+		}
+		if present > 1 {
+			return result{
+				Name:      name,
+				CrossTalk: true,
+				Note:      "multiple goroutines' markers accumulated in the same query (pollution under concurrency)",
+			}
+		}
+	}
+	for _, m := range markers {
+		if counts[m] != 1 {
+			return result{
+				Name:      name,
+				CrossTalk: true,
+				Note:      fmt.Sprintf("marker %q appeared %d time(s), want 1 (overwritten by a racing goroutine)", m, counts[m]),
+			}
+		}
+	}
+	return result{Name: name, CrossTalk: false, Note: "each goroutine's query contained only its own marker"}
+}
@@ -3,9 +3,12 @@
 package main
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -139,14 +142,139 @@ var dbDumper = godump.NewDumper(
 	),
 )
 
-// isPolluted uses godump to detect if Statement changed
+// Fingerprint produces a stable, canonical hash of the pollution-relevant
+// parts of db.Statement - sorted Clauses keys and their serialized
+// expressions, Selects, Omits, Joins, Preloads, Table, Distinct, and
+// ColumnMapping - independent of Go's nondeterministic map iteration order.
+// Two uses motivate it: (1) an easer/cacher-style plugin can use it as a
+// request key to deduplicate in-flight identical queries, the technique
+// go-gorm/caches' easer relies on; (2) the survey can emit a per-method
+// fingerprint table that CI diffs against a golden file to catch GORM
+// version regressions that change query shape without changing the clone
+// value.
+func Fingerprint(db *gorm.DB) string {
+	return fingerprintStatement(db.Statement)
+}
+
+// fingerprintStatement is Fingerprint's *gorm.Statement-level core, kept
+// separate so isPolluted/getPollutionDiff can compare two Statements
+// without needing a *gorm.DB wrapper around each.
+func fingerprintStatement(stmt *gorm.Statement) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "table=%s\n", stmt.Table)
+	fmt.Fprintf(&b, "distinct=%v\n", stmt.Distinct)
+	fmt.Fprintf(&b, "selects=%s\n", strings.Join(sortedCopy(stmt.Selects), ","))
+	fmt.Fprintf(&b, "omits=%s\n", strings.Join(sortedCopy(stmt.Omits), ","))
+
+	// stmt.Joins is a slice of an unexported gorm-internal type, so its
+	// exported fields are read via reflection the same way snapshotStatement
+	// above reads Preloads.
+	joins := make([]string, len(stmt.Joins))
+	for i := range stmt.Joins {
+		jv := reflect.ValueOf(stmt.Joins[i])
+		name := ""
+		if f := jv.FieldByName("Name"); f.IsValid() {
+			name = fmt.Sprintf("%v", f.Interface())
+		}
+		condsLen := 0
+		if f := jv.FieldByName("Conds"); f.IsValid() {
+			condsLen = f.Len()
+		}
+		joins[i] = fmt.Sprintf("%s:%d", name, condsLen)
+	}
+	sort.Strings(joins)
+	fmt.Fprintf(&b, "joins=%s\n", strings.Join(joins, ","))
+
+	// Preloads is read via reflection since its key type varies across
+	// GORM versions and isn't always present.
+	rv := reflect.ValueOf(stmt).Elem()
+	if preloads := rv.FieldByName("Preloads"); preloads.IsValid() && !preloads.IsNil() {
+		names := make([]string, 0, preloads.Len())
+		for _, k := range preloads.MapKeys() {
+			names = append(names, fmt.Sprintf("%v", k.Interface()))
+		}
+		sort.Strings(names)
+		fmt.Fprintf(&b, "preloads=%s\n", strings.Join(names, ","))
+	}
+
+	clauseKeys := make([]string, 0, len(stmt.Clauses))
+	for k := range stmt.Clauses {
+		clauseKeys = append(clauseKeys, k)
+	}
+	sort.Strings(clauseKeys)
+	for _, k := range clauseKeys {
+		fmt.Fprintf(&b, "clause[%s]=%s\n", k, fingerprintExpression(stmt.Clauses[k].Expression))
+	}
+
+	colMapping := make([]string, 0, len(stmt.ColumnMapping))
+	for k, v := range stmt.ColumnMapping {
+		colMapping = append(colMapping, fmt.Sprintf("%s->%s", k, v))
+	}
+	sort.Strings(colMapping)
+	fmt.Fprintf(&b, "columnmapping=%s\n", strings.Join(colMapping, ","))
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// fingerprintExpressions serializes a slice of clause.Expression where the
+// slice order is semantically irrelevant (e.g. a Join's ON conditions),
+// sorting the serialized parts so order doesn't affect the hash.
+func fingerprintExpressions(exprs []clause.Expression) string {
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		parts[i] = fingerprintExpression(e)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "&")
+}
+
+// fingerprintExpression serializes a single clause.Expression. clause.Where
+// (and And/Or groupings) are commutative under AND/OR, so their sub-exprs
+// are sorted before joining; clause.Expr normalizes its SQL text (collapsing
+// whitespace) before hashing so formatting differences don't change the
+// fingerprint. Everything else falls back to a Go-syntax dump, which is
+// deterministic per value even though it isn't hand-normalized.
+func fingerprintExpression(expr clause.Expression) string {
+	switch e := expr.(type) {
+	case clause.Where:
+		return "AND(" + fingerprintExpressions(e.Exprs) + ")"
+	case clause.AndConditions:
+		return "AND(" + fingerprintExpressions(e.Exprs) + ")"
+	case clause.OrConditions:
+		return "OR(" + fingerprintExpressions(e.Exprs) + ")"
+	case clause.Expr:
+		return fmt.Sprintf("expr(%s;%v)", normalizeSQL(e.SQL), e.Vars)
+	default:
+		return fmt.Sprintf("%#v", expr)
+	}
+}
+
+// normalizeSQL collapses whitespace so equivalent SQL text with different
+// spacing fingerprints identically.
+func normalizeSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
+
+// sortedCopy returns a sorted copy of ss, leaving the original slice (and
+// its order, which callers like Statement.Selects may still rely on
+// elsewhere) untouched.
+func sortedCopy(ss []string) []string {
+	out := append([]string(nil), ss...)
+	sort.Strings(out)
+	return out
+}
+
+// isPolluted is now a thin wrapper around Fingerprint so comparisons are
+// reproducible across runs instead of depending on godump's JSON dump,
+// which was sensitive to Go's map iteration order.
 func isPolluted(before, after *gorm.Statement) bool {
-	beforeJSON := statementDumper.DumpJSONStr(before)
-	afterJSON := statementDumper.DumpJSONStr(after)
-	return beforeJSON != afterJSON
+	return fingerprintStatement(before) != fingerprintStatement(after)
 }
 
-// getPollutionDiff uses godump to show what changed
+// getPollutionDiff still uses godump for a human-readable diff - Fingerprint
+// is a hash by design and isn't meant to be a diff source.
 func getPollutionDiff(before, after *gorm.Statement) string {
 	return godump.DiffStr(before, after)
 }
@@ -289,6 +417,21 @@ func main() {
 	fmt.Println("\n=== CRITICAL FINDING ===")
 	fmt.Println("If Preload callback's clone=0, the callback's mutations")
 	fmt.Println("will accumulate on repeated queries! (GitHub #7662)")
+
+	// Per-method fingerprint table: a golden file of these hashes lets CI
+	// catch a GORM version that changes a method's query shape even when
+	// its clone value stays the same.
+	fmt.Println("\n=== FINGERPRINT TABLE (stable hash, diff against a golden file in CI) ===")
+	fmt.Println("Method                  | Fingerprint")
+	fmt.Println("------------------------|-----------------")
+	db4, _ := setupDB()
+	fmt.Printf("%-23s | %s\n", "Where()", Fingerprint(db4.Model(&User{}).Where("x = ?", 1)))
+	fmt.Printf("%-23s | %s\n", "Where() x2 (AND)", Fingerprint(db4.Model(&User{}).Where("x = ?", 1).Where("y = ?", 2)))
+	fmt.Printf("%-23s | %s\n", "Where() x2 swapped", Fingerprint(db4.Model(&User{}).Where("y = ?", 2).Where("x = ?", 1)))
+	fmt.Printf("%-23s | %s\n", "Select()", Fingerprint(db4.Model(&User{}).Select("id", "name")))
+	fmt.Printf("%-23s | %s\n", "Joins()", Fingerprint(db4.Model(&User{}).Joins("Profile")))
+	fmt.Printf("%-23s | %s\n", "Order()", Fingerprint(db4.Model(&User{}).Order("id")))
+	fmt.Println("(the two Where() x2 rows above should match - Where.Exprs are AND-commutative)")
 }
 
 func cloneToSafe(clone int64) string {
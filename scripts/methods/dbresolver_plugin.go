@@ -0,0 +1,13 @@
+//go:build gorm_dbresolver
+
+package main
+
+import "gorm.io/plugin/dbresolver"
+
+// init registers gorm.io/plugin/dbresolver's DBResolver type as an
+// enumeration root and teaches shouldEnumerateType to recurse into the
+// dbresolver package.
+func init() {
+	pluginPackagePrefixes["dbresolver."] = "dbresolver"
+	pluginRootTypes = append(pluginRootTypes, &dbresolver.DBResolver{})
+}
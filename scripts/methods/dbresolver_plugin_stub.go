@@ -0,0 +1,6 @@
+//go:build !gorm_dbresolver
+
+package main
+
+// gorm.io/plugin/dbresolver isn't imported in this build; nothing to register.
+func init() {}
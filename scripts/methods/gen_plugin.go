@@ -0,0 +1,16 @@
+//go:build gorm_gen
+
+package main
+
+import "gorm.io/gen"
+
+// init registers gorm.io/gen's DO/Executor types as enumeration roots and
+// teaches shouldEnumerateType to recurse into the gen package, mirroring
+// how generics.go feeds the Generics API interfaces into the enumeration.
+func init() {
+	pluginPackagePrefixes["gen."] = "gen"
+	pluginRootTypes = append(pluginRootTypes,
+		&gen.DO{},
+		(*gen.Executor)(nil),
+	)
+}
@@ -0,0 +1,6 @@
+//go:build !gorm_gen
+
+package main
+
+// gorm.io/gen isn't imported in this build; nothing to register.
+func init() {}
@@ -0,0 +1,9 @@
+//go:build !gorm_v125plus
+
+package main
+
+import "reflect"
+
+// getGenericsAPITypes is a stub for pre-v1.25 GORM, which has no Generics
+// API interfaces (gorm.PreloadBuilder, gorm.JoinBuilder) to enumerate.
+func getGenericsAPITypes() []reflect.Type { return nil }
@@ -0,0 +1,104 @@
+//go:build gorm_v125plus
+
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// genericSampleTypes are the concrete types the survey instantiates each
+// discovered generic gorm function with, e.g. G[User], G[Order].
+var genericSampleTypes = []string{"User", "Order"}
+
+// enumerateGenericsViaTypes loads gorm.io/gorm with go/packages and scans
+// its package scope for exported generic functions (G[T] and any future
+// additions), returning one MethodInfo per function per sample
+// instantiation with TypeParams/Constraints/Signature populated from
+// go/types. reflect can't see type parameters at all - g := gorm.G[User]
+// shows up to reflect as an opaque interface value - so this is the only
+// path that can describe the parameterized signature.
+func enumerateGenericsViaTypes() ([]MethodInfo, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax | packages.NeedDeps,
+	}
+	pkgs, err := packages.Load(cfg, "gorm.io/gorm")
+	if err != nil {
+		return nil, fmt.Errorf("enumerateGenericsViaTypes: loading gorm.io/gorm: %w", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("enumerateGenericsViaTypes: gorm.io/gorm failed to type-check")
+	}
+
+	var infos []MethodInfo
+	for _, pkg := range pkgs {
+		if pkg.Types == nil {
+			continue
+		}
+		scope := pkg.Types.Scope()
+		names := scope.Names()
+		sort.Strings(names)
+		for _, name := range names {
+			obj := scope.Lookup(name)
+			if obj == nil || !obj.Exported() {
+				continue
+			}
+			fn, ok := obj.(*types.Func)
+			if !ok {
+				continue
+			}
+			sig, ok := fn.Type().(*types.Signature)
+			if !ok || sig.TypeParams() == nil || sig.TypeParams().Len() == 0 {
+				continue
+			}
+			infos = append(infos, genericMethodInfos(fn, sig)...)
+		}
+	}
+	return infos, nil
+}
+
+// genericMethodInfos builds one MethodInfo per genericSampleTypes entry
+// for the generic function fn, recording its type parameters and
+// constraints from sig and a display signature with the first type
+// parameter substituted by the sample type name.
+func genericMethodInfos(fn *types.Func, sig *types.Signature) []MethodInfo {
+	var typeParams, constraints []string
+	for i := 0; i < sig.TypeParams().Len(); i++ {
+		tp := sig.TypeParams().At(i)
+		typeParams = append(typeParams, tp.Obj().Name())
+		constraints = append(constraints, tp.Constraint().String())
+	}
+
+	baseSig := types.TypeString(sig, nil)
+
+	out := make([]MethodInfo, 0, len(genericSampleTypes))
+	for _, sample := range genericSampleTypes {
+		out = append(out, MethodInfo{
+			Name:        fmt.Sprintf("%s[%s]", fn.Name(), sample),
+			TypeParams:  typeParams,
+			Constraints: constraints,
+			Signature:   instantiateSignatureDisplay(fn.Name(), typeParams, baseSig, sample),
+		})
+	}
+	return out
+}
+
+// instantiateSignatureDisplay textually substitutes typeParams[0] with
+// sample in sig for a readable instantiated signature (e.g.
+// "func(tx *DB) ChainInterface[T]" -> "G[User](tx *DB) ChainInterface[User]").
+// This is textual rather than a real types.Instantiate, since the survey
+// only needs a readable instantiated signature, not a type-checked one.
+func instantiateSignatureDisplay(name string, typeParams []string, sig, sample string) string {
+	out := strings.TrimPrefix(sig, "func")
+	if len(typeParams) > 0 {
+		tp := typeParams[0]
+		out = strings.ReplaceAll(out, "["+tp+"]", "["+sample+"]")
+		out = strings.ReplaceAll(out, " "+tp+",", " "+sample+",")
+		out = strings.ReplaceAll(out, " "+tp+")", " "+sample+")")
+	}
+	return name + "[" + sample + "]" + out
+}
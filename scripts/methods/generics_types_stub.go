@@ -0,0 +1,7 @@
+//go:build !gorm_v125plus
+
+package main
+
+// enumerateGenericsViaTypes is a stub for pre-v1.25 GORM, which has no
+// Generics API to enumerate.
+func enumerateGenericsViaTypes() ([]MethodInfo, error) { return nil, nil }
@@ -0,0 +1,12 @@
+//go:build gorm_hints
+
+package main
+
+import "gorm.io/hints"
+
+// init registers gorm.io/hints' Hints type as an enumeration root and
+// teaches shouldEnumerateType to recurse into the hints package.
+func init() {
+	pluginPackagePrefixes["hints."] = "hints"
+	pluginRootTypes = append(pluginRootTypes, hints.Hints{})
+}
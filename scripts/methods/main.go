@@ -12,6 +12,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
+
+	"github.com/mpyw/gorm-purity-survey/analysis/ssapurity"
 )
 
 // MethodInfo holds information about a method.
@@ -26,6 +28,20 @@ type MethodInfo struct {
 	TakesDBFunc bool     `json:"takes_db_func"` // Takes func that receives/returns *gorm.DB
 	Variadic    bool     `json:"variadic"`
 	Signature   string   `json:"signature"`
+
+	// TypeParams and Constraints are filled in for generic methods
+	// discovered via go/types (enumerateGenericsViaTypes) - reflect
+	// can't see type parameters at all, only the post-instantiation
+	// interface, so these are empty for reflect-discovered MethodInfo.
+	TypeParams  []string `json:"type_params,omitempty"`
+	Constraints []string `json:"constraints,omitempty"`
+
+	// SSAClassification is filled in for *gorm.DB methods by
+	// applySSAClassification: a data-flow-derived verdict
+	// (Pure/CopyOnWrite/Mutating/Unknown) in place of the ReturnsDB
+	// heuristic above, which can't tell a chain point from a method that
+	// merely happens to return *gorm.DB unrelated to the receiver.
+	SSAClassification string `json:"ssa_classification,omitempty"`
 }
 
 // TypeMethods holds methods for a specific type.
@@ -34,6 +50,27 @@ type TypeMethods struct {
 	MethodCount  int          `json:"method_count"`
 	Methods      []MethodInfo `json:"methods"`
 	DerivedTypes []string     `json:"derived_types,omitempty"` // Types returned by methods
+	Plugin       string       `json:"plugin,omitempty"`        // e.g. "gen", "hints", "dbresolver"; empty for core gorm/schema/clause
+}
+
+// pluginRootTypes and pluginPackagePrefixes are populated by the
+// build-tag-gated *_plugin.go files (gen_plugin.go, hints_plugin.go,
+// dbresolver_plugin.go) so optional plugin surfaces only get enumerated
+// when the matching gorm_gen/gorm_hints/gorm_dbresolver tag is set.
+var (
+	pluginRootTypes       []interface{}
+	pluginPackagePrefixes = map[string]string{}
+)
+
+// pluginForType reports which registered plugin (if any) owns typeName,
+// based on the package prefixes the *_plugin.go files registered.
+func pluginForType(typeName string) string {
+	for prefix, plugin := range pluginPackagePrefixes {
+		if strings.Contains(typeName, prefix) {
+			return plugin
+		}
+	}
+	return ""
 }
 
 // EnumerationResult holds the complete enumeration result.
@@ -71,6 +108,11 @@ func main() {
 	// These interfaces hold internal *gorm.DB and need investigation
 	interfaceTypes := getGenericsAPITypes()
 
+	// Add optional plugin surfaces (gorm.io/gen, gorm.io/hints,
+	// gorm.io/plugin/dbresolver), registered via build tag in their
+	// respective *_plugin.go files.
+	rootTypes = append(rootTypes, pluginRootTypes...)
+
 	for _, root := range rootTypes {
 		t := reflect.TypeOf(root)
 		enumerator.enumerateRecursive(t)
@@ -88,6 +130,21 @@ func main() {
 		enumerator.enumerateRecursive(t)
 	}
 
+	// Replace the ReturnsDB heuristic with a real SSA-derived
+	// classification for *gorm.DB methods, where available.
+	applySSAClassification(enumerator.result)
+
+	// Enumerate the Generics API (G[T] and friends) via go/types, which
+	// sees type parameters and constraints that reflect cannot. This is
+	// additive: the reflect-based PreloadBuilder/JoinBuilder interface
+	// enumeration above (via getGenericsAPITypes) still runs as the
+	// fallback for consumers who don't want an x/tools dependency.
+	if genericInfos, err := enumerateGenericsViaTypes(); err != nil {
+		fmt.Fprintf(os.Stderr, "generics (go/types) enumeration skipped: %v\n", err)
+	} else if len(genericInfos) > 0 {
+		mergeGenericTypeInfos(enumerator.result, genericInfos)
+	}
+
 	// Analyze pollution paths
 	pollutionPaths := enumerator.findPollutionPaths()
 
@@ -202,27 +259,81 @@ func (e *TypeEnumerator) enumerateRecursive(t reflect.Type) {
 		MethodCount:  len(methods),
 		Methods:      methods,
 		DerivedTypes: derivedList,
+		Plugin:       pluginForType(typeName),
 	}
 }
 
+// applySSAClassification runs ssapurity.AnalyzePackage against gorm.io/gorm
+// and copies each method's data-flow-derived Classification onto the
+// matching *gorm.DB MethodInfo entry in result. It's best-effort: SSA
+// construction needs the real gorm.io/gorm source on disk, so failures
+// (e.g. no module cache available) are reported on stderr and otherwise
+// ignored, leaving SSAClassification empty.
+func applySSAClassification(result map[string]TypeMethods) {
+	tm, ok := result["*gorm.DB"]
+	if !ok {
+		return
+	}
+
+	classifications, err := ssapurity.AnalyzePackage("gorm.io/gorm")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ssa classification skipped: %v\n", err)
+		return
+	}
+
+	byName := make(map[string]ssapurity.Classification, len(classifications))
+	for _, c := range classifications {
+		byName[c.Name] = c.Classification
+	}
+
+	for i, m := range tm.Methods {
+		if c, ok := byName[m.Name]; ok {
+			tm.Methods[i].SSAClassification = string(c)
+		}
+	}
+	result["*gorm.DB"] = tm
+}
+
+// genericsTypesKey is the synthetic TypeMethods key go/types-discovered
+// generic functions (G[T] and friends) are recorded under, since they're
+// package-level functions rather than a single reflect-discoverable type.
+const genericsTypesKey = "gorm.G (generics, go/types)"
+
+// mergeGenericTypeInfos records infos (from enumerateGenericsViaTypes)
+// under genericsTypesKey, appending to whatever's already there across
+// multiple discovered generic functions.
+func mergeGenericTypeInfos(result map[string]TypeMethods, infos []MethodInfo) {
+	tm := result[genericsTypesKey]
+	tm.TypeName = genericsTypesKey
+	tm.Methods = append(tm.Methods, infos...)
+	sort.Slice(tm.Methods, func(i, j int) bool { return tm.Methods[i].Name < tm.Methods[j].Name })
+	tm.MethodCount = len(tm.Methods)
+	result[genericsTypesKey] = tm
+}
+
 func (e *TypeEnumerator) findPollutionPaths() []string {
 	var paths []string
 
 	for typeName, tm := range e.result {
+		prefix := ""
+		if tm.Plugin != "" {
+			prefix = "[" + tm.Plugin + "] "
+		}
+
 		for _, m := range tm.Methods {
 			// Methods that take *gorm.DB directly
 			if m.TakesDB {
-				paths = append(paths, fmt.Sprintf("%s.%s takes *gorm.DB directly", typeName, m.Name))
+				paths = append(paths, fmt.Sprintf("%s%s.%s takes *gorm.DB directly", prefix, typeName, m.Name))
 			}
 
 			// Methods that take func(*gorm.DB)
 			if m.TakesDBFunc {
-				paths = append(paths, fmt.Sprintf("%s.%s takes func with *gorm.DB", typeName, m.Name))
+				paths = append(paths, fmt.Sprintf("%s%s.%s takes func with *gorm.DB", prefix, typeName, m.Name))
 			}
 
 			// Methods that return *gorm.DB (potential chain point)
 			if m.ReturnsDB {
-				paths = append(paths, fmt.Sprintf("%s.%s returns *gorm.DB (chain point)", typeName, m.Name))
+				paths = append(paths, fmt.Sprintf("%s%s.%s returns *gorm.DB (chain point)", prefix, typeName, m.Name))
 			}
 		}
 	}
@@ -285,6 +396,13 @@ func shouldEnumerateType(t reflect.Type) bool {
 		}
 	}
 
+	// ...or a registered plugin surface (gen./hints./dbresolver.)
+	for prefix := range pluginPackagePrefixes {
+		if strings.Contains(typeName, prefix) {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+// expectAnyExec sets up mock to accept any non-Begin/Commit exec, the
+// Exec-flavored counterpart to expectAnyQuery.
+func expectAnyExec(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// testJoinsConditions covers the Joins(query, conditionDB) form alongside
+// testJoins' plain Joins(query) case - GORM lets a Joins call carry an
+// eager-loading condition as a second *gorm.DB argument, which is a
+// separate code path from a bare join string and so gets its own pollution
+// check per the request.
+func testJoinsConditions(result *PurityResult) {
+	m := MethodResult{Name: "Joins(with conditions)", Exists: true}
+	defer func() { result.Methods["Joins(with conditions)"] = m }()
+
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{})
+	base.Joins("Profile", db.Where("pollution_marker_col = ?", true))
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	if m.Pure != nil && !*m.Pure {
+		m.PureNote = "Joins(query, conditionDB) pollutes receiver when result discarded"
+	}
+}
+
+// associationOp is one *gorm.Association mutator under test.
+type associationOp struct {
+	Name    string
+	Mutate  func(assoc *gorm.Association) error
+	IsWrite bool // true for Append/Replace/Delete/Clear, false for Count
+}
+
+// associationOps covers the mutators named in the request. Profile is a
+// has-one association on User (see the User/Profile models in main.go),
+// so every op below targets "Profile".
+var associationOps = []associationOp{
+	{Name: "Append", IsWrite: true, Mutate: func(a *gorm.Association) error { return a.Append(&Profile{ID: 2, UserID: 1}) }},
+	{Name: "Replace", IsWrite: true, Mutate: func(a *gorm.Association) error { return a.Replace(&Profile{ID: 3, UserID: 1}) }},
+	{Name: "Delete", IsWrite: true, Mutate: func(a *gorm.Association) error { return a.Delete(&Profile{ID: 2, UserID: 1}) }},
+	{Name: "Clear", IsWrite: true, Mutate: func(a *gorm.Association) error { return a.Clear() }},
+	{Name: "Count", IsWrite: false, Mutate: func(a *gorm.Association) error { a.Count(); return a.Error }},
+}
+
+// runAssociationTests probes each associationOp for receiver pollution:
+// does building tx := db.Model(user).Where(marker).Association("Profile")
+// and running the mutator leave marker on db, the handle tx was built
+// from, for an unrelated later query on db to pick up, the association-mode
+// analog of the *Association("Languages")
+// shared-Statement bugs named in the request.
+func runAssociationTests(result *PurityResult) {
+	for _, op := range associationOps {
+		testAssociationMutator(result, op)
+	}
+}
+
+func testAssociationMutator(result *PurityResult, op associationOp) {
+	name := "Association." + op.Name
+	m := MethodResult{Name: name, Exists: true}
+	defer func() { result.Methods[name] = m }()
+
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	user := &User{ID: 1}
+	tx := db.Model(user).Where("pollution_marker_col = ?", true)
+
+	if op.IsWrite {
+		mock.ExpectBegin()
+		expectAnyExec(mock)
+		mock.ExpectCommit()
+	} else {
+		expectAnyQuery(mock)
+	}
+
+	if err := op.Mutate(tx.Association("Profile")); err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	cap.Reset()
+	expectAnyQuery(mock)
+	var users []User
+	db.Model(&User{}).Find(&users)
+
+	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	if m.Pure != nil && !*m.Pure {
+		m.PureNote = "Association(\"Profile\")." + op.Name + " pollutes the *gorm.DB its chain was built from"
+	}
+}
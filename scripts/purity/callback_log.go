@@ -0,0 +1,151 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// CallbackEntry records what a registered plugin-style callback saw at the
+// moment Before("gorm:<op>") fired: the *gorm.Statement's identity (so two
+// entries can be compared for "same Statement instance"), its clone
+// counter, and which clauses were already present on it.
+type CallbackEntry struct {
+	Operation    string
+	StatementPtr uintptr
+	Clone        int
+	Clauses      []string
+}
+
+// CallbackLog accumulates CallbackEntry values across every callback chain
+// a real plugin would hook, generalizing testScopes's one-off "peek at tx
+// inside the scope function" into a reusable probe installable on any
+// *gorm.DB - see installCallbackLog.
+type CallbackLog struct {
+	entries []CallbackEntry
+}
+
+// Entries returns every recorded entry so far, in the order the callbacks
+// fired.
+func (l *CallbackLog) Entries() []CallbackEntry {
+	return l.entries
+}
+
+// Last returns the most recently recorded entry, or nil if none were
+// recorded yet.
+func (l *CallbackLog) Last() *CallbackEntry {
+	if len(l.entries) == 0 {
+		return nil
+	}
+	e := l.entries[len(l.entries)-1]
+	return &e
+}
+
+// EntriesFor returns every recorded entry for the given operation name, in
+// the order the callbacks fired - useful for methods like Save/FirstOrCreate that can
+// fire more than one callback chain (e.g. "query" then "create") within a
+// single call, where comparing the log's last two entries overall would
+// compare two different operations instead of the same operation across
+// two calls.
+func (l *CallbackLog) EntriesFor(op string) []CallbackEntry {
+	var out []CallbackEntry
+	for _, e := range l.entries {
+		if e.Operation == op {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// DistinctFromPreviousFor is EntriesFor's counterpart to
+// DistinctFromPrevious: it compares the last two recorded entries for a
+// single operation, ignoring any other operation interleaved between them.
+func (l *CallbackLog) DistinctFromPreviousFor(op string) *bool {
+	entries := l.EntriesFor(op)
+	if len(entries) < 2 {
+		return nil
+	}
+	distinct := entries[len(entries)-1].StatementPtr != entries[len(entries)-2].StatementPtr
+	return &distinct
+}
+
+// DistinctFromPrevious reports whether the most recently recorded entry's
+// Statement pointer differs from the one recorded just before it - direct
+// proof that two finisher calls did not share a backing *gorm.Statement,
+// which is the structural question the method this log is installed on
+// is actually being asked: "did the Statement genuinely carry the marker
+// clause", not "did sqlmock's captured SQL text happen to omit it".
+// Returns nil if fewer than two entries have been recorded yet.
+func (l *CallbackLog) DistinctFromPrevious() *bool {
+	if len(l.entries) < 2 {
+		return nil
+	}
+	distinct := l.entries[len(l.entries)-1].StatementPtr != l.entries[len(l.entries)-2].StatementPtr
+	return &distinct
+}
+
+// callbackOps lists the six callback chains installCallbackLog hooks,
+// mirroring tests/plugins/registered_callback_test.go's
+// registeredCallbackOps table (that file probes clone values at every
+// (operation, phase) pair in isolation, one *gorm.DB per subtest; this one
+// wires all six chains onto a single *gorm.DB so a testXxx function can
+// consult one shared log across an entire run instead of writing its own
+// one-off Scopes-style peek).
+var callbackOps = []struct {
+	Name     string
+	Register func(db *gorm.DB, id string, fn func(*gorm.DB)) error
+}{
+	{Name: "query", Register: func(db *gorm.DB, id string, fn func(*gorm.DB)) error {
+		return db.Callback().Query().Before("gorm:query").Register(id, fn)
+	}},
+	{Name: "create", Register: func(db *gorm.DB, id string, fn func(*gorm.DB)) error {
+		return db.Callback().Create().Before("gorm:create").Register(id, fn)
+	}},
+	{Name: "update", Register: func(db *gorm.DB, id string, fn func(*gorm.DB)) error {
+		return db.Callback().Update().Before("gorm:update").Register(id, fn)
+	}},
+	{Name: "delete", Register: func(db *gorm.DB, id string, fn func(*gorm.DB)) error {
+		return db.Callback().Delete().Before("gorm:delete").Register(id, fn)
+	}},
+	{Name: "row", Register: func(db *gorm.DB, id string, fn func(*gorm.DB)) error {
+		return db.Callback().Row().Before("gorm:row").Register(id, fn)
+	}},
+	{Name: "raw", Register: func(db *gorm.DB, id string, fn func(*gorm.DB)) error {
+		return db.Callback().Raw().Before("gorm:raw").Register(id, fn)
+	}},
+}
+
+// installCallbackLog registers a probe on every callback chain in
+// callbackOps and returns the CallbackLog they append to. Call this right
+// after setupDB, before building any query, so the log captures every
+// finisher invocation for the rest of the test.
+func installCallbackLog(db *gorm.DB) (*CallbackLog, error) {
+	log := &CallbackLog{}
+	for _, op := range callbackOps {
+		op := op
+		probe := func(tx *gorm.DB) {
+			log.entries = append(log.entries, CallbackEntry{
+				Operation:    op.Name,
+				StatementPtr: reflect.ValueOf(tx.Statement).Pointer(),
+				Clone:        getCloneValue(tx),
+				Clauses:      clauseNames(tx),
+			})
+		}
+		if err := op.Register(db, "purity-survey:"+op.Name, probe); err != nil {
+			return nil, err
+		}
+	}
+	return log, nil
+}
+
+// clauseNames returns the sorted set of clause keys present on tx's
+// Statement at the moment the callback fired.
+func clauseNames(tx *gorm.DB) []string {
+	names := make([]string, 0, len(tx.Statement.Clauses))
+	for name := range tx.Statement.Clauses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// dialectNames are the dialects runDialectMatrix reruns runCoreTests
+// under. Each claims a different Name() and renders bindvars/identifiers
+// the way its real driver does, since several GORM behaviors (Clauses
+// rewriting, OnConflict, LIMIT/OFFSET handling) branch on dialect name.
+var dialectNames = []string{"postgres", "mysql", "sqlite", "sqlserver"}
+
+// dialectorFor returns the Dialector for the named dialect, wired to conn
+// the same way setupDB wires MockDialector.
+func dialectorFor(name string, conn *sql.DB) (gorm.Dialector, error) {
+	switch name {
+	case "postgres":
+		return MockDialector{Conn: conn}, nil
+	case "mysql":
+		return mysqlMockDialector{Conn: conn}, nil
+	case "sqlite":
+		return sqliteMockDialector{Conn: conn}, nil
+	case "sqlserver":
+		return sqlserverMockDialector{Conn: conn}, nil
+	default:
+		return nil, fmt.Errorf("dialectorFor: unknown dialect %q", name)
+	}
+}
+
+// mysqlMockDialector mimics MySQL's '?' bindvar and backtick quoting.
+type mysqlMockDialector struct {
+	Conn *sql.DB
+}
+
+func (d mysqlMockDialector) Name() string { return "mysql" }
+func (d mysqlMockDialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = d.Conn
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+func (d mysqlMockDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return migrator.Migrator{Config: migrator.Config{DB: db, Dialector: d, CreateIndexAfterCreateTable: true}}
+}
+func (d mysqlMockDialector) DataTypeOf(field *schema.Field) string { return "TEXT" }
+func (d mysqlMockDialector) DefaultValueOf(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "NULL"}
+}
+func (d mysqlMockDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteByte('?')
+}
+func (d mysqlMockDialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteByte('`')
+	writer.WriteString(str)
+	writer.WriteByte('`')
+}
+func (d mysqlMockDialector) Explain(sql string, vars ...interface{}) string { return sql }
+
+// sqliteMockDialector mimics SQLite's '?' bindvar and double-quote
+// quoting (same rendering as MockDialector, distinct Name()).
+type sqliteMockDialector struct {
+	Conn *sql.DB
+}
+
+func (d sqliteMockDialector) Name() string { return "sqlite" }
+func (d sqliteMockDialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = d.Conn
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+func (d sqliteMockDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return migrator.Migrator{Config: migrator.Config{DB: db, Dialector: d, CreateIndexAfterCreateTable: true}}
+}
+func (d sqliteMockDialector) DataTypeOf(field *schema.Field) string { return "TEXT" }
+func (d sqliteMockDialector) DefaultValueOf(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "NULL"}
+}
+func (d sqliteMockDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteByte('?')
+}
+func (d sqliteMockDialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteByte('"')
+	writer.WriteString(str)
+	writer.WriteByte('"')
+}
+func (d sqliteMockDialector) Explain(sql string, vars ...interface{}) string { return sql }
+
+// sqlserverMockDialector mimics SQL Server's positional '@pN' bindvars and
+// bracket quoting - the two renderings most likely to interact badly with
+// a chain method that builds SQL fragments assuming '?'/double-quote
+// (e.g. a raw Clauses(clause.Expr{...}) built for postgres).
+type sqlserverMockDialector struct {
+	Conn *sql.DB
+}
+
+func (d sqlserverMockDialector) Name() string { return "sqlserver" }
+func (d sqlserverMockDialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = d.Conn
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+func (d sqlserverMockDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return migrator.Migrator{Config: migrator.Config{DB: db, Dialector: d, CreateIndexAfterCreateTable: true}}
+}
+func (d sqlserverMockDialector) DataTypeOf(field *schema.Field) string { return "TEXT" }
+func (d sqlserverMockDialector) DefaultValueOf(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "NULL"}
+}
+func (d sqlserverMockDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteString(fmt.Sprintf("@p%d", len(stmt.Vars)))
+}
+func (d sqlserverMockDialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteByte('[')
+	writer.WriteString(str)
+	writer.WriteByte(']')
+}
+func (d sqlserverMockDialector) Explain(sql string, vars ...interface{}) string { return sql }
+
+// DialectDiff records a method whose Pure verdict isn't the same across
+// every dialect in dialectNames.
+type DialectDiff struct {
+	Method   string          `json:"method"`
+	Verdicts map[string]bool `json:"verdicts"` // dialect name -> Pure
+}
+
+// runDialectMatrix reruns runCoreTests once per dialectNames entry,
+// records each pass under result.DialectMatrix, and surfaces any method
+// whose Pure verdict differs across dialects under
+// result.Summary.DialectDiffs.
+func runDialectMatrix(result *PurityResult) {
+	matrix := make(map[string]map[string]MethodResult, len(dialectNames))
+
+	previous := currentDialect
+	defer func() { currentDialect = previous }()
+
+	for _, name := range dialectNames {
+		currentDialect = name
+		dialectResult := PurityResult{Methods: make(map[string]MethodResult)}
+		runCoreTests(&dialectResult)
+		matrix[name] = dialectResult.Methods
+	}
+
+	result.DialectMatrix = matrix
+	result.Summary.DialectDiffs = diffDialects(matrix)
+	populatePerDialect(result, matrix)
+}
+
+// perDialectMethods lists the methods whose own rendered SQL (quoting,
+// bindvar style, RETURNING, LIMIT/OFFSET ordering, ON CONFLICT) is worth
+// exposing per-dialect directly on the method's own result - see
+// MethodResult.PerDialect.
+var perDialectMethods = []string{"ToSQL", "MapColumns"}
+
+// populatePerDialect pivots matrix (dialect -> method, already computed by
+// runDialectMatrix) into MethodResult.PerDialect for perDialectMethods,
+// without re-running runCoreTests a second time.
+func populatePerDialect(result *PurityResult, matrix map[string]map[string]MethodResult) {
+	for _, name := range perDialectMethods {
+		perDialect := make(map[string]MethodResult, len(dialectNames))
+		for _, dialect := range dialectNames {
+			if mr, ok := matrix[dialect][name]; ok {
+				perDialect[dialect] = mr
+			}
+		}
+		if len(perDialect) == 0 {
+			continue
+		}
+		m := result.Methods[name]
+		m.PerDialect = perDialect
+		result.Methods[name] = m
+	}
+}
+
+// diffDialects compares Pure verdicts for every method present across all
+// of matrix's dialects, returning one DialectDiff per method that isn't
+// unanimous.
+func diffDialects(matrix map[string]map[string]MethodResult) []DialectDiff {
+	methodNames := make(map[string]struct{})
+	for _, methods := range matrix {
+		for name := range methods {
+			methodNames[name] = struct{}{}
+		}
+	}
+	sortedMethods := make([]string, 0, len(methodNames))
+	for name := range methodNames {
+		sortedMethods = append(sortedMethods, name)
+	}
+	sort.Strings(sortedMethods)
+
+	var diffs []DialectDiff
+	for _, method := range sortedMethods {
+		verdicts := make(map[string]bool)
+		seen := make(map[bool]bool)
+		for dialect, methods := range matrix {
+			m, ok := methods[method]
+			if !ok || m.Pure == nil {
+				continue
+			}
+			verdicts[dialect] = *m.Pure
+			seen[*m.Pure] = true
+		}
+		if len(seen) > 1 {
+			diffs = append(diffs, DialectDiff{Method: method, Verdicts: verdicts})
+		}
+	}
+	return diffs
+}
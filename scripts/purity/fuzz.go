@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ChainWitness is a minimized reproducer for a fuzz failure: splitting
+// Methods at BranchPoint into a shared prefix Methods[:BranchPoint] and two
+// sibling branches both continuing with Methods[BranchPoint:] let LeftMarker,
+// threaded only down the left branch, leak into the right branch's SQL.
+type ChainWitness struct {
+	Methods     []string `json:"methods"`
+	BranchPoint int      `json:"branch_point"`
+	LeftMarker  string   `json:"left_marker"`
+	Note        string   `json:"note"`
+}
+
+const (
+	fuzzDefaultTrials = 2000
+	fuzzMinDepth      = 2
+	fuzzMaxDepth      = 6
+
+	fuzzLeftMarker  = "left_branch_marker_col"
+	fuzzRightMarker = "right_branch_marker_col"
+)
+
+// runFuzzTests generates fuzzDefaultTrials (override via GORM_FUZZ_TRIALS)
+// random chain-method sequences of depth fuzzMinDepth..fuzzMaxDepth, splits
+// each at a random branch point into two sibling queries off a shared
+// prefix, and records a minimized witness for every sequence where a marker
+// threaded only down the left branch leaks into the right branch's
+// captured SQL - catching cross-method leakage (e.g. Joins polluting a
+// later sibling) that main.go's per-method tests, each run in isolation,
+// cannot see.
+func runFuzzTests(result *PurityResult) {
+	rng := newFuzzRNG()
+
+	trials := fuzzDefaultTrials
+	if v := os.Getenv("GORM_FUZZ_TRIALS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			trials = n
+		}
+	}
+
+	for i := 0; i < trials; i++ {
+		depth := fuzzMinDepth + rng.Intn(fuzzMaxDepth-fuzzMinDepth+1)
+		seq := randomFuzzSequence(rng, depth)
+		branchPoint := 1 + rng.Intn(depth-1)
+
+		if witness := runFuzzSequence(seq, branchPoint); witness != nil {
+			shrunk := shrinkWitness(*witness)
+			result.FuzzFindings = append(result.FuzzFindings, shrunk)
+		}
+	}
+}
+
+// newFuzzRNG seeds from GORM_FUZZ_SEED for reproducible reruns, falling
+// back to the current time.
+func newFuzzRNG() *rand.Rand {
+	seed := time.Now().UnixNano()
+	if v := os.Getenv("GORM_FUZZ_SEED"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			seed = n
+		}
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// randomFuzzSequence picks depth method names from raceProbes (the same
+// chain-method set race.go exercises under goroutine concurrency).
+func randomFuzzSequence(rng *rand.Rand, depth int) []string {
+	seq := make([]string, depth)
+	for i := range seq {
+		seq[i] = raceProbes[rng.Intn(len(raceProbes))].Name
+	}
+	return seq
+}
+
+// applyFuzzMethod chains the named raceProbes method onto db with marker as
+// its argument.
+func applyFuzzMethod(db *gorm.DB, name, marker string) *gorm.DB {
+	for _, p := range raceProbes {
+		if p.Name == name {
+			return p.Branch(db, marker)
+		}
+	}
+	return db
+}
+
+// runFuzzSequence builds the shared prefix methods[:branchPoint], then
+// forks two sibling queries that both continue with methods[branchPoint:],
+// one threading fuzzLeftMarker and one fuzzRightMarker. If the left
+// branch's marker shows up in the right branch's captured SQL, the prefix
+// query was mutated by the left branch rather than returning an isolated
+// clone to fork from.
+func runFuzzSequence(methods []string, branchPoint int) *ChainWitness {
+	if branchPoint < 1 || branchPoint >= len(methods) {
+		return nil
+	}
+
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return nil
+	}
+	mock.MatchExpectationsInOrder(false)
+
+	prefixDB := db.Model(&User{}).Where("base_cond = ?", true)
+	for _, name := range methods[:branchPoint] {
+		prefixDB = applyFuzzMethod(prefixDB, name, "prefix_marker_col")
+	}
+
+	cap.Reset()
+	qL := prefixDB
+	for _, name := range methods[branchPoint:] {
+		qL = applyFuzzMethod(qL, name, fuzzLeftMarker)
+	}
+	expectAnyQuery(mock)
+	var usersL []User
+	qL.Find(&usersL)
+	cap.Reset()
+
+	qR := prefixDB
+	for _, name := range methods[branchPoint:] {
+		qR = applyFuzzMethod(qR, name, fuzzRightMarker)
+	}
+	expectAnyQuery(mock)
+	var usersR []User
+	qR.Find(&usersR)
+	sqlR := strings.ToLower(strings.Join(cap.AllSQL(), " "))
+
+	if strings.Contains(sqlR, strings.ToLower(fuzzLeftMarker)) {
+		return &ChainWitness{
+			Methods:     append([]string(nil), methods...),
+			BranchPoint: branchPoint,
+			LeftMarker:  fuzzLeftMarker,
+			Note:        fmt.Sprintf("sequence %v split at %d: left branch's marker leaked into the right branch's SQL", methods, branchPoint),
+		}
+	}
+	return nil
+}
+
+// shrinkWitness repeatedly tries dropping one method from the failing
+// sequence (classic property-test shrinking), keeping the shrink whenever
+// the smaller sequence still reproduces, until no single removal
+// reproduces anymore.
+func shrinkWitness(w ChainWitness) ChainWitness {
+	seq := append([]string(nil), w.Methods...)
+	branch := w.BranchPoint
+
+	for {
+		shrunkAny := false
+		for i := 0; i < len(seq); i++ {
+			trial := make([]string, 0, len(seq)-1)
+			trial = append(trial, seq[:i]...)
+			trial = append(trial, seq[i+1:]...)
+
+			trialBranch := branch
+			if i < branch {
+				trialBranch--
+			}
+			if trialBranch < 1 || trialBranch > len(trial)-1 {
+				continue
+			}
+
+			if witness := runFuzzSequence(trial, trialBranch); witness != nil {
+				seq, branch = trial, trialBranch
+				shrunkAny = true
+				break
+			}
+		}
+		if !shrunkAny {
+			break
+		}
+	}
+
+	return ChainWitness{
+		Methods:     seq,
+		BranchPoint: branch,
+		LeftMarker:  w.LeftMarker,
+		Note:        fmt.Sprintf("minimized: sequence %v split at %d: left branch's marker leaked into the right branch's SQL", seq, branch),
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mpyw/gorm-purity-survey/report"
+)
+
+// runPurityGate converts result to a report.Report and fails if any
+// method's finding reaches minSeverity without the checked-in baseline
+// (see baselinePath) already having reached it at that severity - see
+// report.Gate. This is the same regression check cmd/purity-report
+// performs from the command line against two JSON files, available here
+// as a plain function so a single `go run ./scripts/purity` invocation can
+// gate in-process instead of piping its own output back through a second
+// binary.
+func runPurityGate(result *PurityResult, minSeverity report.Severity) error {
+	path := baselinePath()
+	// No baseline yet: nothing to regress against, so the run passes.
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+	baseline, err := loadBaseline(path)
+	if err != nil {
+		return fmt.Errorf("runPurityGate: %w", err)
+	}
+
+	regressions := report.Gate(result.ToReport(), baseline, minSeverity)
+	if len(regressions) == 0 {
+		return nil
+	}
+
+	for _, r := range regressions {
+		fmt.Fprintf(os.Stderr, "gorm-purity-survey: REGRESSION %s (%s): %s\n", r.Method, r.Severity, r.Note)
+	}
+	return fmt.Errorf("runPurityGate: %d method(s) regressed at or above severity %q", len(regressions), minSeverity)
+}
+
+// baselinePath is GORM_PURITY_BASELINE if set, else "baseline.json" in the
+// working directory - the same env-var-with-default shape main() already
+// uses for GORM_VERSION.
+func baselinePath() string {
+	if p := os.Getenv("GORM_PURITY_BASELINE"); p != "" {
+		return p
+	}
+	return "baseline.json"
+}
+
+// loadBaseline reads the baseline report at path. A missing file is not an
+// error - a repo adopting the gate for the first time has nothing to
+// regress against yet, so every current finding is implicitly accepted
+// into the baseline rather than failing the first run.
+func loadBaseline(path string) (report.Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return report.Report{}, nil
+		}
+		return report.Report{}, err
+	}
+	var r report.Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return report.Report{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return r, nil
+}
@@ -9,6 +9,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"strings"
@@ -20,10 +21,15 @@ import (
 	"gorm.io/gorm/migrator"
 	"gorm.io/gorm/schema"
 
+	"github.com/mpyw/gorm-purity-survey/report"
+	"github.com/mpyw/gorm-purity-survey/sqlcheck"
 	"github.com/mpyw/gorm-purity-survey/tests/capture"
 )
 
-// MockDialector is a minimal dialector for sqlmock testing (PostgreSQL style).
+// MockDialector is a minimal dialector for sqlmock testing (PostgreSQL
+// style, '?' bindvar / '"' quoting). It's also the default dialector
+// setupDB opens against; see dialects.go for the mysql/sqlite/sqlserver
+// siblings the multi-dialect matrix runs the same suite under.
 type MockDialector struct {
 	Conn *sql.DB
 }
@@ -34,7 +40,9 @@ func (d MockDialector) Initialize(db *gorm.DB) error {
 	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
 	return nil
 }
-func (d MockDialector) Migrator(db *gorm.DB) gorm.Migrator    { return migrator.Migrator{} }
+func (d MockDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return migrator.Migrator{Config: migrator.Config{DB: db, Dialector: d, CreateIndexAfterCreateTable: true}}
+}
 func (d MockDialector) DataTypeOf(field *schema.Field) string { return "TEXT" }
 func (d MockDialector) DefaultValueOf(field *schema.Field) clause.Expression {
 	return clause.Expr{SQL: "NULL"}
@@ -66,20 +74,82 @@ type Profile struct {
 
 // MethodResult holds test results for a single method.
 type MethodResult struct {
-	Name                   string  `json:"name"`
-	Exists                 bool    `json:"exists"`
-	Pure                   *bool   `json:"pure,omitempty"`                     // nil if not testable
-	ImpureMode             *string `json:"impure_mode,omitempty"`              // "accumulate" or "overwrite" (only if pure=false)
-	ImmutableReturn        *bool   `json:"immutable_return,omitempty"`         // nil if not testable
-	ReturnClone            *int    `json:"return_clone,omitempty"`             // clone value of returned *gorm.DB (0=no clone, 1=stmt clone, 2=full clone)
-	CallbackArgImmutable   *bool   `json:"callback_arg_immutable,omitempty"`   // nil if method doesn't take callback
-	CallbackClone          *int    `json:"callback_clone,omitempty"`           // clone value of callback's *gorm.DB argument
-	FinisherPreservesJoins *bool   `json:"finisher_preserves_joins,omitempty"` // For Count: are Joins preserved after execution?
-	PureNote               string  `json:"pure_note,omitempty"`
-	ImmutableNote          string  `json:"immutable_note,omitempty"`
-	CallbackNote           string  `json:"callback_note,omitempty"`
-	FinisherNote           string  `json:"finisher_note,omitempty"`
-	Error                  string  `json:"error,omitempty"`
+	Name                      string  `json:"name"`
+	Exists                    bool    `json:"exists"`
+	Pure                      *bool   `json:"pure,omitempty"`                        // nil if not testable
+	ImpureMode                *string `json:"impure_mode,omitempty"`                 // "accumulate" or "overwrite" (only if pure=false)
+	ImmutableReturn           *bool   `json:"immutable_return,omitempty"`            // nil if not testable
+	ReturnClone               *int    `json:"return_clone,omitempty"`                // clone value of returned *gorm.DB (0=no clone, 1=stmt clone, 2=full clone)
+	CallbackArgImmutable      *bool   `json:"callback_arg_immutable,omitempty"`      // nil if method doesn't take callback
+	CallbackClone             *int    `json:"callback_clone,omitempty"`              // clone value of callback's *gorm.DB argument
+	FinisherPreservesJoins    *bool   `json:"finisher_preserves_joins,omitempty"`    // For Count: are Joins preserved after execution?
+	FinisherPreservesPreloads *bool   `json:"finisher_preserves_preloads,omitempty"` // For Preload: are Preloads preserved after a non-Find finisher runs first?
+	PureNote                  string  `json:"pure_note,omitempty"`
+	ImmutableNote             string  `json:"immutable_note,omitempty"`
+	CallbackNote              string  `json:"callback_note,omitempty"`
+	FinisherNote              string  `json:"finisher_note,omitempty"`
+	Error                     string  `json:"error,omitempty"`
+
+	// PluginProfiles holds this method's Pure/CallbackArgImmutable
+	// results rerun under each installed-plugin scenario (see
+	// runPluginMatrix), keyed by scenario name, so a plugin that changes
+	// a method's isolation guarantees shows up next to the vanilla
+	// result above instead of only in a separate report.
+	PluginProfiles map[string]PluginProfile `json:"plugin_profiles,omitempty"`
+
+	// RaceFree reports whether concurrent goroutines sharing a base
+	// *gorm.DB and calling this method with distinct markers stayed
+	// isolated from each other (see race.go). nil if not tested; run the
+	// survey with `go run -race` for this to catch actual data races,
+	// not just SQL cross-talk.
+	RaceFree *bool  `json:"race_free,omitempty"`
+	RaceNote string `json:"race_note,omitempty"`
+
+	// TransactionCallbackImmutable reports whether Transaction's
+	// func(tx *gorm.DB) error callback argument is isolated across
+	// repeated db.Transaction(fn) calls with the same closure (see
+	// transaction.go) - the same callback-arg-immutability shape as the
+	// Preload regression (#7662), just for the transaction lifecycle.
+	TransactionCallbackImmutable *bool `json:"transaction_callback_immutable,omitempty"`
+
+	// ASTDiff lists columns that sqlcheck found referenced in the
+	// polluted branch's WHERE tree but not in the base query's WHERE
+	// tree (see testWhere). This is an additive, structural cross-check
+	// on top of the substring-based Pure determination above - populated
+	// for Where only for now rather than rewritten across every testXxx,
+	// since the substring check already catches everything the repo's
+	// mock SQL shapes can produce and a full AST rewrite isn't worth the
+	// churn it would cause.
+	ASTDiff []string `json:"ast_diff,omitempty"`
+
+	// CallbackStatementIsolated reports whether two consecutive finisher
+	// calls, observed through a registered plugin-style callback (see
+	// callback_log.go's CallbackLog), received distinct *gorm.Statement
+	// instances. This is the Statement-identity counterpart to Pure
+	// above: Pure infers isolation from sqlmock's captured SQL text,
+	// while this is direct proof the two calls didn't share a backing
+	// Statement at all, regardless of what either one rendered to SQL.
+	CallbackStatementIsolated *bool `json:"callback_statement_isolated,omitempty"`
+
+	// PerDialect holds this method's own per-dialect MethodResult,
+	// pivoted from PurityResult.DialectMatrix after runDialectMatrix
+	// finishes (see dialects.go's populatePerDialect). Populated only
+	// for methods whose own rendered SQL is sensitive enough to
+	// quoting/bindvar/clause differences (ToSQL, MapColumns) that it's
+	// worth a dialect-keyed copy attached directly to the method, rather
+	// than making a caller cross-reference DialectMatrix by hand - every
+	// other method already gets the same per-dialect comparison via
+	// DialectMatrix/Summary.DialectDiffs without needing its own copy.
+	PerDialect map[string]MethodResult `json:"per_dialect,omitempty"`
+
+	// PureUnderPrepareStmt reports this method's Pure verdict rerun with
+	// setupDB opening a PrepareStmt-mode session (see preparestmt.go's
+	// runPrepareStmtMatrix). GORM's PreparedStmtDB caches rendered SQL
+	// keyed by statement text, a different pollution surface than the
+	// plain-session Pure check above: a method can leave the receiver
+	// untouched yet still get its polluted branch's SQL cached and reused
+	// by an unrelated later query that happens to render the same text.
+	PureUnderPrepareStmt *bool `json:"pure_under_prepare_stmt,omitempty"`
 }
 
 // PurityResult holds the complete purity test result.
@@ -87,6 +157,17 @@ type PurityResult struct {
 	GormVersion string                  `json:"gorm_version"`
 	Methods     map[string]MethodResult `json:"methods"`
 	Summary     Summary                 `json:"summary"`
+
+	// FuzzFindings holds minimized witnesses from runFuzzTests (see
+	// fuzz.go): randomly generated chain-method sequences where a marker
+	// threaded only down one branch leaked into its sibling branch's SQL.
+	FuzzFindings []ChainWitness `json:"fuzz_findings,omitempty"`
+
+	// DialectMatrix holds the core purity tests rerun under each dialect
+	// in dialectNames (see dialects.go), keyed by dialect then method
+	// name, so dialect-only mutation bugs aren't hidden behind the
+	// top-level postgres-only Methods result.
+	DialectMatrix map[string]map[string]MethodResult `json:"dialect_matrix,omitempty"`
 }
 
 // Summary holds summary statistics.
@@ -98,6 +179,10 @@ type Summary struct {
 	MutableCount      int `json:"mutable_count"`
 	CallbackImmutable int `json:"callback_immutable"`
 	CallbackMutable   int `json:"callback_mutable"`
+
+	// DialectDiffs lists methods whose Pure verdict differs across
+	// dialects in DialectMatrix (see diffDialects in dialects.go).
+	DialectDiffs []DialectDiff `json:"dialect_diffs,omitempty"`
 }
 
 func main() {
@@ -122,13 +207,45 @@ func main() {
 	// Calculate summary
 	calculateSummary(&result)
 
-	// Output JSON
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(result); err != nil {
-		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+	// Output: this binary's own detailed JSON schema by default: set
+	// GORM_PURITY_REPORT_FORMAT=junit or =sarif to instead emit
+	// result.ToReport() in the report package's CI-friendly formats (see
+	// report_emit.go).
+	if err := writeResult(os.Stdout, &result, os.Getenv("GORM_PURITY_REPORT_FORMAT")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Optional CI gate: set GORM_PURITY_GATE to a report.Severity value
+	// ("leaks_clause", "leaks_callbacks", "leaks_schema", ...) to fail
+	// this run when a method regresses to at least that severity versus
+	// the checked-in baseline (see gate.go).
+	if minSeverity := os.Getenv("GORM_PURITY_GATE"); minSeverity != "" {
+		if err := runPurityGate(&result, report.Severity(minSeverity)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// writeResult serializes result per format: "junit" and "sarif" go through
+// result.ToReport(), and "report" writes that same report.Report as JSON
+// (the format cmd/purity-scripts-matrix reads back in); anything else
+// (including "") is this binary's own detailed PurityResult JSON,
+// unchanged from before report_emit.go existed.
+func writeResult(w io.Writer, result *PurityResult, format string) error {
+	switch format {
+	case "junit":
+		return result.ToReport().WriteJUnit(w)
+	case "sarif":
+		return result.ToReport().WriteSARIF(w)
+	case "report":
+		return result.ToReport().WriteJSON(w)
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
 }
 
 func calculateSummary(result *PurityResult) {
@@ -161,8 +278,19 @@ func calculateSummary(result *PurityResult) {
 	}
 }
 
-// setupDB creates a GORM DB with sqlmock and SQL capture.
+// setupDB creates a GORM DB with sqlmock and SQL capture, using whichever
+// dialect runDialectMatrix (see dialects.go) currently has active -
+// "postgres" outside of that matrix.
 func setupDB() (*gorm.DB, sqlmock.Sqlmock, *capture.SQLCapture, error) {
+	return setupDBWithDialect(currentDialect)
+}
+
+// setupDBWithDialect is setupDB parameterized by dialect name (one of
+// dialectNames in dialects.go). Each dialect's Dialector claims a
+// different Name(), BindVarTo, and QuoteTo so dialect-specific rendering
+// (bindvar style, identifier quoting) shows up in captured SQL the same
+// way it would against a real driver.
+func setupDBWithDialect(name string) (*gorm.DB, sqlmock.Sqlmock, *capture.SQLCapture, error) {
 	mockDB, mock, err := sqlmock.New()
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to create sqlmock: %w", err)
@@ -170,13 +298,22 @@ func setupDB() (*gorm.DB, sqlmock.Sqlmock, *capture.SQLCapture, error) {
 
 	cap := capture.New()
 
-	gormDB, err := gorm.Open(MockDialector{Conn: mockDB}, &gorm.Config{
+	dialector, err := dialectorFor(name, mockDB)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	gormDB, err := gorm.Open(dialector, &gorm.Config{
 		Logger: cap.LogMode(4), // Info level = 4
 	})
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to open gorm: %w", err)
 	}
 
+	if currentPrepareStmt {
+		gormDB = gormDB.Session(&gorm.Session{PrepareStmt: true})
+	}
+
 	return gormDB, mock, cap, nil
 }
 
@@ -201,7 +338,52 @@ func expectAnyQuery(mock sqlmock.Sqlmock) {
 	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
 }
 
+// currentDialect is which dialects.go Dialector setupDB opens against;
+// runDialectMatrix flips it for the duration of each per-dialect
+// runCoreTests pass and restores "postgres" afterward.
+var currentDialect = "postgres"
+
+// currentPrepareStmt toggles whether setupDBWithDialect wraps the opened
+// *gorm.DB with Session(&gorm.Session{PrepareStmt: true}); see
+// preparestmt.go's runPrepareStmtMatrix, which flips this the same way
+// runDialectMatrix flips currentDialect.
+var currentPrepareStmt = false
+
 func runAllTests(result *PurityResult) {
+	runCoreTests(result)
+
+	// Plugin-installed matrix: rerun a representative subset of methods
+	// once per installed-plugin scenario (see plugin_matrix.go).
+	runPluginMatrix(result)
+
+	// Concurrency/race dimension: rerun the chain methods across
+	// goroutines sharing one base (see race.go).
+	runRaceTests(result)
+
+	// Property-based chain-sequence fuzzing: generate random chain
+	// sequences and look for cross-method leakage the per-method tests
+	// above, each run in isolation, cannot see (see fuzz.go).
+	runFuzzTests(result)
+
+	// Multi-dialect matrix: rerun the core tests under mysql/sqlite/
+	// sqlserver/postgres dialectors and surface verdict differences
+	// (see dialects.go). Runs after the plugin/race/fuzz dimensions; it
+	// doesn't need any of them and is the most expensive (one full core
+	// pass per dialect).
+	runDialectMatrix(result)
+
+	// PrepareStmt-mode matrix: rerun the core tests with every session
+	// opened under PrepareStmt: true and surface a second, cache-specific
+	// purity verdict per method (see preparestmt.go).
+	runPrepareStmtMatrix(result)
+}
+
+// runCoreTests runs the per-method purity/immutable-return/callback-arg
+// tests against whichever dialect setupDB currently opens against. This
+// is what runDialectMatrix reruns per-dialect (see dialects.go) - kept
+// separate from the plugin/race/fuzz dimensions above so that rerun
+// doesn't also redo those, which are dialect-independent.
+func runCoreTests(result *PurityResult) {
 	// === Chain Methods (return *gorm.DB) ===
 	testWhere(result)
 	testOr(result)
@@ -211,7 +393,13 @@ func runAllTests(result *PurityResult) {
 	testGroup(result)
 	testHaving(result)
 	testJoins(result)
+	testJoinsConditions(result)
 	testPreload(result)
+
+	// *Association("Rel") mutators (see associations.go): a separate
+	// surface from the chain/finisher methods above, since *gorm.Association
+	// isn't a *gorm.DB and has its own shared-Statement pollution risk.
+	runAssociationTests(result)
 	testDistinct(result)
 	testLimit(result)
 	testOffset(result)
@@ -231,6 +419,13 @@ func runAllTests(result *PurityResult) {
 	testDebug(result)
 	testBegin(result)
 
+	// Transaction lifecycle (see transaction.go): Begin is covered above,
+	// the rest of the lifecycle - the Transaction callback shape and
+	// SavePoint/RollbackTo's returned *gorm.DB - below.
+	testTransactionCallback(result)
+	testSavePoint(result)
+	testRollbackTo(result)
+
 	// === Finishers (purity test only) ===
 	testFind(result)
 	testFirst(result)
@@ -250,8 +445,20 @@ func runAllTests(result *PurityResult) {
 	testFirstOrCreate(result)
 	testFirstOrInit(result)
 
+	// db.Migrator()'s DDL methods (see migrator.go): a stateful Migrator
+	// holds the *gorm.DB it was obtained from, a different pollution
+	// surface than the chain/finisher methods above.
+	runMigratorTests(result)
+
 	// Version-specific methods (added via build tags)
 	runVersionSpecificTests(result)
+
+	// Plugin-surface methods (gorm.io/gen, gorm.io/hints,
+	// gorm.io/plugin/dbresolver), each gated behind its own build tag
+	// since they're optional dependencies independent of GORM version.
+	runDBResolverTests(result)
+	runGenTests(result)
+	runHintsTests(result)
 }
 
 // =============================================================================
@@ -337,6 +544,15 @@ func testWhere(result *PurityResult) {
 	}
 
 	q := db2.Model(&User{}).Where("base = ?", true)
+
+	// Base query's own SQL, built under DryRun before any branch runs so
+	// it neither executes nor picks up a branch's condition.
+	var baseStmt *sqlcheck.Statement
+	var dryUsers []User
+	if dry := q.Session(&gorm.Session{DryRun: true}).Find(&dryUsers); dry.Error == nil {
+		baseStmt = sqlcheck.Parse(dry.Statement.SQL.String())
+	}
+
 	mock2.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
 	var r1 []User
 	q.Where("branch_one_col = ?", true).Find(&r1)
@@ -350,6 +566,16 @@ func testWhere(result *PurityResult) {
 	if m.ImmutableReturn != nil && !*m.ImmutableReturn {
 		m.ImmutableNote = "Where return value is mutable (branches interfere)"
 	}
+
+	// === AST CROSS-CHECK ===
+	// Structural companion to the substring check above: parse r2's SQL
+	// and diff its WHERE tree against the base query's, so a leaked
+	// predicate shows up as a named column instead of only as a boolean
+	// flip.
+	if sqls := cap2.AllSQL(); baseStmt != nil && len(sqls) > 0 {
+		branchStmt := sqlcheck.Parse(sqls[len(sqls)-1])
+		m.ASTDiff = sqlcheck.Diff(baseStmt, branchStmt)
+	}
 }
 
 func testOr(result *PurityResult) {
@@ -743,10 +969,92 @@ func testPreload(result *PurityResult) {
 	m := MethodResult{Name: "Preload", Exists: true}
 	defer func() { result.Methods["Preload"] = m }()
 
-	// Preload modifies Statement.Preloads, not directly visible in main SQL
-	// The callback test is the critical one for detecting v1.30.0 regression
-	m.Pure = ptr(true) // Assume pure for now, callback test is more important
-	m.PureNote = "Preload modifies Statement.Preloads (not visible in main SQL)"
+	// === PURE TEST ===
+	// Preload's own marker lives in the preload callback's WHERE clause,
+	// which shows up as a second captured query (the association SELECT)
+	// rather than in the main query's SQL.
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{})
+	base.Preload("Profile", func(tx *gorm.DB) *gorm.DB { return tx.Where("pollution_marker_col = ?", true) })
+	expectAnyQuery(mock)
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	if m.Pure != nil && !*m.Pure {
+		m.PureNote = "Preload pollutes receiver when result discarded"
+	}
+
+	// === IMPURE MODE TEST ===
+	if m.Pure != nil && !*m.Pure {
+		db3, mock3, cap3, err := setupDB()
+		if err == nil {
+			base3 := db3.Model(&User{})
+			base3.Preload("Profile", func(tx *gorm.DB) *gorm.DB { return tx.Where("first_marker_col = ?", 1) })
+			base3.Preload("Profile", func(tx *gorm.DB) *gorm.DB { return tx.Where("second_marker_col = ?", 2) })
+			expectAnyQuery(mock3)
+			expectAnyQuery(mock3)
+			var users3 []User
+			base3.Find(&users3)
+
+			hasFirst := cap3.ContainsNormalized("first_marker_col")
+			hasSecond := cap3.ContainsNormalized("second_marker_col")
+			if hasFirst && hasSecond {
+				m.ImpureMode = ptr("accumulate")
+			} else if hasSecond && !hasFirst {
+				m.ImpureMode = ptr("overwrite")
+			}
+		}
+	}
+
+	// === IMMUTABLE-RETURN TEST ===
+	db2, mock2, cap2, err := setupDB()
+	if err == nil {
+		q := db2.Model(&User{}).Where("base = ?", true)
+
+		expectAnyQuery(mock2)
+		expectAnyQuery(mock2)
+		var r1 []User
+		q.Preload("Profile", func(tx *gorm.DB) *gorm.DB { return tx.Where("branch_one_col = ?", true) }).Find(&r1)
+
+		cap2.Reset()
+		expectAnyQuery(mock2)
+		expectAnyQuery(mock2)
+		var r2 []User
+		q.Preload("Profile", func(tx *gorm.DB) *gorm.DB { return tx.Where("branch_two_col = ?", true) }).Find(&r2)
+
+		m.ImmutableReturn = ptr(!cap2.ContainsNormalized("branch_one_col"))
+	}
+
+	// === FINISHER PRESERVES PRELOADS TEST ===
+	// Mirrors testCount's FinisherPreservesJoins (PR #7027): does running
+	// a non-Find finisher first clear the Preloads clause before a later
+	// Find() on the same query tries to reuse it?
+	db4, mock4, cap4, err := setupDB()
+	if err == nil {
+		q4 := db4.Model(&User{}).Preload("Profile")
+
+		mock4.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		var count int64
+		q4.Count(&count)
+
+		cap4.Reset()
+		expectAnyQuery(mock4)
+		expectAnyQuery(mock4)
+		var users4 []User
+		q4.Find(&users4)
+
+		m.FinisherPreservesPreloads = ptr(len(cap4.AllSQL()) >= 2)
+		if m.FinisherPreservesPreloads != nil && !*m.FinisherPreservesPreloads {
+			m.FinisherNote = "BUG: Count() clears Preloads before a later Find() reuses the same query (mirrors PR #7027 for Joins)"
+		}
+	}
 
 	// === CALLBACK ARG IMMUTABILITY TEST ===
 	// This is the critical test for v1.30.0 regression
@@ -1319,6 +1627,12 @@ func testFind(result *PurityResult) {
 		return
 	}
 
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
 	base := db.Model(&User{}).Where("base = ?", true)
 
 	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
@@ -1332,6 +1646,10 @@ func testFind(result *PurityResult) {
 	base.Where("second = ?", "clean").Find(&r2)
 
 	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 }
 
 func testFirst(result *PurityResult) {
@@ -1345,6 +1663,12 @@ func testFirst(result *PurityResult) {
 		return
 	}
 
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
 	base := db.Model(&User{}).Where("base = ?", true)
 
 	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}).AddRow(1, "test", "admin"))
@@ -1358,6 +1682,10 @@ func testFirst(result *PurityResult) {
 	base.Where("second = ?", "clean").First(&r2)
 
 	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 }
 
 func testTake(result *PurityResult) {
@@ -1423,6 +1751,12 @@ func testCount(result *PurityResult) {
 		return
 	}
 
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
 	base := db.Model(&User{}).Where("base = ?", true)
 
 	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
@@ -1436,6 +1770,10 @@ func testCount(result *PurityResult) {
 	base.Where("second = ?", "clean").Count(&c2)
 
 	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 
 	// === FINISHER PRESERVES JOINS TEST ===
 	// PR #7027: Count() was clearing Joins in some versions
@@ -1496,28 +1834,100 @@ func testScan(result *PurityResult) {
 	m := MethodResult{Name: "Scan", Exists: true}
 	defer func() { result.Methods["Scan"] = m }()
 
-	// Scan is similar to Find - it's a finisher
-	// Testing from mutable base like other finishers
-	m.Pure = ptr(true) // Assume same behavior as Find
-	m.PureNote = "Scan behaves similarly to Find (finisher)"
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{}).Where("base = ?", true)
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r1 []User
+	base.Where("pollution_marker_col = ?", true).Scan(&r1)
+
+	cap.Reset()
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r2 []User
+	base.Where("second = ?", "clean").Scan(&r2)
+
+	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 }
 
 func testRow(result *PurityResult) {
 	m := MethodResult{Name: "Row", Exists: true}
 	defer func() { result.Methods["Row"] = m }()
 
-	// Row is a finisher that returns a single *sql.Row
-	m.Pure = ptr(true) // Assume same behavior as Find
-	m.PureNote = "Row behaves similarly to Find (finisher)"
+	db, mock, _, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{}).Where("base = ?", true)
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	base.Where("pollution_marker_col = ?", true).Row()
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	base.Where("second = ?", "clean").Row()
+
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
+	m.Pure = m.CallbackStatementIsolated
+	m.PureNote = "Row purity is judged from CallbackLog Statement identity, not SQL capture, since .Row() returns a *sql.Row with no second capturable query to diff against"
 }
 
 func testRows(result *PurityResult) {
 	m := MethodResult{Name: "Rows", Exists: true}
 	defer func() { result.Methods["Rows"] = m }()
 
-	// Rows is a finisher that returns *sql.Rows
-	m.Pure = ptr(true) // Assume same behavior as Find
-	m.PureNote = "Rows behaves similarly to Find (finisher)"
+	db, mock, _, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{}).Where("base = ?", true)
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	if rows, rerr := base.Where("pollution_marker_col = ?", true).Rows(); rerr == nil {
+		rows.Close()
+	}
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	if rows, rerr := base.Where("second = ?", "clean").Rows(); rerr == nil {
+		rows.Close()
+	}
+
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
+	m.Pure = m.CallbackStatementIsolated
+	m.PureNote = "Rows purity is judged from CallbackLog Statement identity, not SQL capture, for the same reason as Row"
 }
 
 func testCreate(result *PurityResult) {
@@ -1548,8 +1958,32 @@ func testSave(result *PurityResult) {
 	m := MethodResult{Name: "Save", Exists: true}
 	defer func() { result.Methods["Save"] = m }()
 
-	m.Pure = ptr(true)
-	m.PureNote = "Save behavior is complex (upsert), assumed pure"
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{})
+
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(1, 1))
+	base.Where("pollution_marker_col = ?", true).Save(&User{Name: "test1"})
+
+	cap.Reset()
+
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(2, 1))
+	base.Where("second = ?", "clean").Save(&User{Name: "test2"})
+
+	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 }
 
 func testUpdate(result *PurityResult) {
@@ -1587,6 +2021,12 @@ func testUpdates(result *PurityResult) {
 		return
 	}
 
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
 	base := db.Model(&User{}).Where("id = ?", 1)
 
 	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 1))
@@ -1598,6 +2038,10 @@ func testUpdates(result *PurityResult) {
 	base.Where("second = ?", "clean").Updates(map[string]interface{}{"name": "updated2"})
 
 	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 }
 
 func testDelete(result *PurityResult) {
@@ -1611,6 +2055,12 @@ func testDelete(result *PurityResult) {
 		return
 	}
 
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
 	base := db.Model(&User{}).Where("id = ?", 1)
 
 	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 1))
@@ -1622,6 +2072,10 @@ func testDelete(result *PurityResult) {
 	base.Where("second = ?", "clean").Delete(&User{})
 
 	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 }
 
 func testExec(result *PurityResult) {
@@ -1635,6 +2089,12 @@ func testExec(result *PurityResult) {
 		return
 	}
 
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
 	base := db.Model(&User{})
 
 	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 1))
@@ -1646,14 +2106,51 @@ func testExec(result *PurityResult) {
 	base.Exec("UPDATE users SET name = ? WHERE id = ?", "test", 2)
 
 	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	m.CallbackStatementIsolated = log.DistinctFromPrevious()
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 }
 
 func testFirstOrCreate(result *PurityResult) {
 	m := MethodResult{Name: "FirstOrCreate", Exists: true}
 	defer func() { result.Methods["FirstOrCreate"] = m }()
 
-	m.Pure = ptr(true)
-	m.PureNote = "FirstOrCreate behavior is complex, assumed pure"
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+	log, err := installCallbackLog(db)
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{})
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(1, 1))
+	var u1 User
+	base.Where("pollution_marker_col = ?", true).FirstOrCreate(&u1, User{Name: "test1"})
+
+	cap.Reset()
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(2, 1))
+	var u2 User
+	base.Where("second = ?", "clean").FirstOrCreate(&u2, User{Name: "test2"})
+
+	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	// FirstOrCreate fires both the query and create callback chains per
+	// call (query to look the row up, create if it wasn't found), so the
+	// cross-call identity check has to compare like-for-like via the
+	// "create" operation specifically rather than the log's last two
+	// entries overall, which would straddle the two different chains.
+	m.CallbackStatementIsolated = log.DistinctFromPreviousFor("create")
+	if e := log.Last(); e != nil {
+		m.CallbackClone = ptr(e.Clone)
+	}
 }
 
 func testFirstOrInit(result *PurityResult) {
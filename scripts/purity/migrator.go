@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+// expectAnyDDL sets up mock to accept an open-ended sequence of
+// introspection queries and DDL execs in any order - a single Migrator()
+// call (AutoMigrate especially) can emit an unpredictable number of each,
+// unlike the single query/exec expectAnyQuery/expectAnyExec cover.
+func expectAnyDDL(mock sqlmock.Sqlmock) {
+	mock.MatchExpectationsInOrder(false)
+	for i := 0; i < 64; i++ {
+		mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"column_name"}))
+		mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+}
+
+// migratorMarkerModel is a model distinct from User/Profile, so a migrator
+// op targeting it can't be confused with the base query's own table in
+// captured SQL - its TableName and only non-ID field both carry the
+// pollution marker.
+type migratorMarkerModel struct {
+	ID                 uint
+	PollutionMarkerCol string
+}
+
+func (migratorMarkerModel) TableName() string { return "pollution_marker_table" }
+
+// migratorOp is one db.Migrator() method under test.
+type migratorOp struct {
+	Name string
+	Run  func(m gorm.Migrator) error
+}
+
+// migratorOps covers the methods named in the request. AddColumn/AlterColumn/
+// CreateIndex operate on an existing model plus a field/index name, so those
+// three target User with the marker column name instead of a marker model.
+var migratorOps = []migratorOp{
+	{"AutoMigrate", func(m gorm.Migrator) error { return m.AutoMigrate(&migratorMarkerModel{}) }},
+	{"CreateTable", func(m gorm.Migrator) error { return m.CreateTable(&migratorMarkerModel{}) }},
+	{"AddColumn", func(m gorm.Migrator) error { return m.AddColumn(&User{}, "pollution_marker_col") }},
+	{"AlterColumn", func(m gorm.Migrator) error { return m.AlterColumn(&User{}, "pollution_marker_col") }},
+	{"CreateIndex", func(m gorm.Migrator) error { return m.CreateIndex(&User{}, "pollution_marker_col") }},
+	{"DropTable", func(m gorm.Migrator) error { return m.DropTable(&migratorMarkerModel{}) }},
+}
+
+// runMigratorTests probes each migratorOp for receiver pollution: does
+// building base := db.Model(&User{}) and running the op against
+// base.Migrator() leave the marker on base for an unrelated later query to
+// pick up - the Migrator analog of the *Association pollution check in
+// associations.go.
+func runMigratorTests(result *PurityResult) {
+	for _, op := range migratorOps {
+		testMigratorOp(result, op)
+	}
+}
+
+func testMigratorOp(result *PurityResult, op migratorOp) {
+	name := "Migrator()." + op.Name
+	m := MethodResult{Name: name, Exists: true}
+	defer func() {
+		if r := recover(); r != nil {
+			m.Error = fmt.Sprintf("Migrator().%s panicked: %v", op.Name, r)
+		}
+		result.Methods[name] = m
+	}()
+
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{})
+	expectAnyDDL(mock)
+	if err := op.Run(base.Migrator()); err != nil {
+		m.Error = err.Error()
+	}
+
+	cap.Reset()
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+	if m.Pure != nil && !*m.Pure {
+		m.PureNote = "Migrator()." + op.Name + " pollutes the *gorm.DB it was obtained from"
+	}
+}
@@ -0,0 +1,40 @@
+//go:build gorm_dbresolver
+
+package main
+
+import (
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+// runDBResolverTests probes gorm.io/plugin/dbresolver's Clauses sentinel
+// (dbresolver.Write/dbresolver.Read), which riders pass through the core
+// *gorm.DB.Clauses chain point to pick a resolver source. It's gated
+// behind its own build tag since dbresolver is an optional dependency.
+func runDBResolverTests(result *PurityResult) {
+	testDBResolverClauses(result)
+}
+
+// testDBResolverClauses mirrors testClauses, but mixes a dbresolver.Write
+// sentinel into the same Clauses() call to confirm the resolver sentinel
+// doesn't change Clauses' pollution behavior across sibling branches.
+func testDBResolverClauses(result *PurityResult) {
+	m := MethodResult{Name: "[dbresolver] Clauses(dbresolver.Write, ...)", Exists: true}
+	defer func() { result.Methods["[dbresolver] Clauses(dbresolver.Write, ...)"] = m }()
+
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	m.ReturnClone = ptr(getCloneValue(db.Clauses(dbresolver.Write, clause.OrderBy{})))
+
+	base := db.Model(&User{})
+	base.Clauses(dbresolver.Write, clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: "POLLUTION_MARKER"}}}})
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	m.Pure = ptr(!cap.ContainsNormalized("POLLUTION_MARKER"))
+}
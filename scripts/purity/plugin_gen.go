@@ -0,0 +1,31 @@
+//go:build gorm_gen
+
+package main
+
+import "gorm.io/gen"
+
+// runGenTests probes gorm.io/gen's generated-DAO surface.
+func runGenTests(result *PurityResult) {
+	testGenDOUnderlyingDB(result)
+}
+
+// testGenDOUnderlyingDB checks that gen.DO exposes the *gorm.DB it wraps
+// via UnderlyingDB(), so downstream pollution analysis of generated DAO
+// code can fall back to the core *gorm.DB purity results for the wrapped
+// handle. gen.DO's internal field layout isn't part of its public API, so
+// unlike the core methods above this doesn't independently verify
+// receiver-mutation purity for gen's own chain methods (Where, Order,
+// ...) - only that the wrapped handle is reachable.
+func testGenDOUnderlyingDB(result *PurityResult) {
+	m := MethodResult{Name: "[gen] DO.UnderlyingDB", Exists: true}
+	defer func() { result.Methods["[gen] DO.UnderlyingDB"] = m }()
+
+	var do gen.DO
+	wrapped := do.UnderlyingDB()
+	if wrapped == nil {
+		m.PureNote = "zero-value gen.DO has no underlying *gorm.DB yet; purity of gen's chain methods is not independently verified here"
+		return
+	}
+
+	m.PureNote = "gen.DO wraps a *gorm.DB reachable via UnderlyingDB(); purity of gen's own chain methods (Where, Order, ...) is not independently verified here"
+}
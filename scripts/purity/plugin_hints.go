@@ -0,0 +1,37 @@
+//go:build gorm_hints
+
+package main
+
+import (
+	"gorm.io/gorm/clause"
+	"gorm.io/hints"
+)
+
+// runHintsTests probes gorm.io/hints' index/comment hint clauses.
+func runHintsTests(result *PurityResult) {
+	testHintsClauses(result)
+}
+
+// testHintsClauses mirrors testClauses, but mixes a hints.New(...) hint
+// expression into the same Clauses() call to confirm hints don't change
+// Clauses' pollution behavior across sibling branches.
+func testHintsClauses(result *PurityResult) {
+	m := MethodResult{Name: "[hints] Clauses(hints.New(...), ...)", Exists: true}
+	defer func() { result.Methods["[hints] Clauses(hints.New(...), ...)"] = m }()
+
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	m.ReturnClone = ptr(getCloneValue(db.Clauses(hints.New("MAX_EXECUTION_TIME(1000)"), clause.OrderBy{})))
+
+	base := db.Model(&User{})
+	base.Clauses(hints.New("MAX_EXECUTION_TIME(1000)"), clause.OrderBy{Columns: []clause.OrderByColumn{{Column: clause.Column{Name: "POLLUTION_MARKER"}}}})
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	m.Pure = ptr(!cap.ContainsNormalized("POLLUTION_MARKER"))
+}
@@ -0,0 +1,6 @@
+//go:build !gorm_hints
+
+package main
+
+// gorm.io/hints isn't imported in this build; nothing to test.
+func runHintsTests(result *PurityResult) {}
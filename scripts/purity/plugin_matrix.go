@@ -0,0 +1,142 @@
+package main
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/mpyw/gorm-purity-survey/tests/capture"
+	"github.com/mpyw/gorm-purity-survey/tests/plugins"
+)
+
+// PluginProfile mirrors the purity dimensions on MethodResult, recorded
+// once per installed-plugin scenario so a plugin that changes a method's
+// isolation guarantees (e.g. "Preload callback is pure on vanilla GORM
+// but becomes impure with caches installed") is visible in the report.
+type PluginProfile struct {
+	Pure                 *bool  `json:"pure,omitempty"`
+	CallbackArgImmutable *bool  `json:"callback_arg_immutable,omitempty"`
+	Note                 string `json:"note,omitempty"`
+}
+
+// pluginScenario names one plugin combination under test, reusing the
+// in-process stand-ins from tests/plugins so the matrix doesn't need a
+// real cache or connection pool.
+type pluginScenario struct {
+	Name    string
+	Plugins []gorm.Plugin
+}
+
+var pluginScenarios = []pluginScenario{
+	{Name: "none"},
+	{Name: "caches", Plugins: []gorm.Plugin{plugins.NewCacherStub(), plugins.NewEaserStub()}},
+	{Name: "dbresolver-like", Plugins: []gorm.Plugin{plugins.ResolverStub{}}},
+}
+
+// setupDBWithPlugins mirrors setupDB, additionally installing plugins via
+// db.Use the way a real application would.
+func setupDBWithPlugins(ps []gorm.Plugin) (*gorm.DB, sqlmock.Sqlmock, *capture.SQLCapture, error) {
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, p := range ps {
+		if err := db.Use(p); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return db, mock, cap, nil
+}
+
+// runPluginMatrix reruns a representative subset of chain/callback
+// methods under each pluginScenario and records the result on the
+// matching MethodResult's PluginProfiles - a full rerun of every method
+// in runAllTests would just duplicate each testX function with plugins
+// installed, so this covers the methods most likely to interact with a
+// plugin's callbacks: a plain chain method (Where) and the
+// callback-bearing methods (Scopes, Preload) where a plugin replacing or
+// sharing the callback's *gorm.DB is the actual hazard.
+func runPluginMatrix(result *PurityResult) {
+	for _, sc := range pluginScenarios {
+		recordPluginProfile(result, "Where", sc, probePluginWhere(sc.Plugins))
+		recordPluginProfile(result, "Scopes", sc, probePluginScopes(sc.Plugins))
+		recordPluginProfile(result, "Preload", sc, probePluginPreload(sc.Plugins))
+	}
+}
+
+func recordPluginProfile(result *PurityResult, method string, sc pluginScenario, profile PluginProfile) {
+	m := result.Methods[method]
+	if m.PluginProfiles == nil {
+		m.PluginProfiles = make(map[string]PluginProfile)
+	}
+	m.PluginProfiles[sc.Name] = profile
+	result.Methods[method] = m
+}
+
+// probePluginWhere mirrors testWhere's pure test, with ps installed.
+func probePluginWhere(ps []gorm.Plugin) PluginProfile {
+	db, mock, cap, err := setupDBWithPlugins(ps)
+	if err != nil {
+		return PluginProfile{Note: err.Error()}
+	}
+
+	base := db.Model(&User{}).Where("base_cond = ?", true)
+	base.Where("pollution_marker_col = ?", true)
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	return PluginProfile{Pure: ptr(!cap.ContainsNormalized("pollution_marker_col"))}
+}
+
+// probePluginScopes mirrors testScopes' pure + callback-arg-immutable
+// tests, with ps installed.
+func probePluginScopes(ps []gorm.Plugin) PluginProfile {
+	db, mock, cap, err := setupDBWithPlugins(ps)
+	if err != nil {
+		return PluginProfile{Note: err.Error()}
+	}
+
+	var callbackClone int = -1
+	base := db.Model(&User{}).Scopes(func(tx *gorm.DB) *gorm.DB {
+		callbackClone = getCloneValue(tx)
+		return tx
+	})
+	pollutingScope := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("POLLUTION_MARKER = ?", true)
+	}
+	base.Scopes(pollutingScope)
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	return PluginProfile{
+		Pure:                 ptr(!cap.ContainsNormalized("POLLUTION_MARKER")),
+		CallbackArgImmutable: ptr(callbackClone > 0),
+	}
+}
+
+// probePluginPreload mirrors testPreload's callback-arg-immutable test,
+// with ps installed - the scenario the request calls out by name
+// ("Preload callback is pure on vanilla GORM but becomes impure with
+// caches installed").
+func probePluginPreload(ps []gorm.Plugin) PluginProfile {
+	db, mock, cap, err := setupDBWithPlugins(ps)
+	if err != nil {
+		return PluginProfile{Note: err.Error()}
+	}
+
+	var callbackClone int = -1
+	base := db.Model(&User{}).Preload("Profile", func(tx *gorm.DB) *gorm.DB {
+		callbackClone = getCloneValue(tx)
+		return tx.Where("POLLUTION_MARKER = ?", true)
+	})
+	expectAnyQuery(mock)
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	var users []User
+	base.Find(&users)
+
+	return PluginProfile{
+		Pure:                 ptr(!cap.ContainsNormalized("POLLUTION_MARKER")),
+		CallbackArgImmutable: ptr(callbackClone > 0),
+	}
+}
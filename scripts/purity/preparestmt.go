@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// runPrepareStmtMatrix reruns runCoreTests once with currentPrepareStmt set,
+// so every setupDB call in that pass opens a PrepareStmt-mode session (see
+// main.go's currentPrepareStmt/setupDBWithDialect), then pivots each
+// method's Pure verdict from that pass into MethodResult.PureUnderPrepareStmt
+// - the same "flip a package var, rerun runCoreTests, pivot the result"
+// shape runDialectMatrix uses for dialects.
+func runPrepareStmtMatrix(result *PurityResult) {
+	previous := currentPrepareStmt
+	currentPrepareStmt = true
+	defer func() { currentPrepareStmt = previous }()
+
+	psResult := PurityResult{Methods: make(map[string]MethodResult)}
+	runCoreTests(&psResult)
+
+	for name, psMethod := range psResult.Methods {
+		m := result.Methods[name]
+		m.PureUnderPrepareStmt = psMethod.Pure
+		if m.Pure != nil && psMethod.Pure != nil && *m.Pure && !*psMethod.Pure {
+			m.PureNote = "pure in a plain session but leaks once PrepareStmt caches the rendered statement"
+		}
+		result.Methods[name] = m
+	}
+
+	testPreparedStmtCacheIsolation(result)
+}
+
+// testPreparedStmtCacheIsolation probes the deeper claim behind
+// PureUnderPrepareStmt directly: does a marker clause built on a discarded
+// branch actually end up cached inside gorm.PreparedStmtDB.Stmts, where
+// PrepareStmt mode would reuse it for every later query that renders the
+// same SQL text? Where stands in for every chain method here - they all
+// render through the same PreparedStmtDB.Stmts cache, so one probe confirms
+// the mechanism without repeating it per method.
+func testPreparedStmtCacheIsolation(result *PurityResult) {
+	const name = "[preparestmt] Where cache isolation"
+	m := MethodResult{Name: name, Exists: true}
+	defer func() { result.Methods[name] = m }()
+
+	previous := currentPrepareStmt
+	currentPrepareStmt = true
+	defer func() { currentPrepareStmt = previous }()
+
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	base := db.Model(&User{}).Where("base_cond = ?", true)
+	base.Where("pollution_marker_col = ?", true)
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	m.Pure = ptr(!cap.ContainsNormalized("pollution_marker_col"))
+
+	psdb, ok := db.ConnPool.(*gorm.PreparedStmtDB)
+	if !ok {
+		m.Error = "ConnPool is not *gorm.PreparedStmtDB; PrepareStmt session did not wrap the connection as expected"
+		return
+	}
+
+	leaked := false
+	for _, sql := range psdb.Stmts.Keys() {
+		if strings.Contains(sql, "pollution_marker_col") {
+			leaked = true
+			break
+		}
+	}
+	m.PureUnderPrepareStmt = ptr(!leaked)
+	if leaked {
+		m.PureNote = "pollution_marker_col ended up cached in PreparedStmtDB.Stmts - a later query rendering the same SQL text would reuse the polluted statement"
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// raceGoroutines is how many goroutines race against a shared base
+// *gorm.DB for each method under test.
+const raceGoroutines = 8
+
+// raceProbe is one chain method exercised under goroutine concurrency.
+type raceProbe struct {
+	Name   string
+	Branch func(base *gorm.DB, marker string) *gorm.DB
+}
+
+// raceProbes covers the chain methods already enumerated in runAllTests
+// that mutate Statement.Clauses/Selects/Joins - the map/slice fields a
+// concurrent `go run -race` build actually flags when two goroutines
+// share a base and race on them, beyond scripts/concurrency's
+// single-process SQL cross-talk check.
+var raceProbes = []raceProbe{
+	{"Where", func(base *gorm.DB, marker string) *gorm.DB { return base.Where(marker + " = ?") }},
+	{"Or", func(base *gorm.DB, marker string) *gorm.DB { return base.Or(marker + " = ?") }},
+	{"Not", func(base *gorm.DB, marker string) *gorm.DB { return base.Not(marker + " = ?") }},
+	{"Select", func(base *gorm.DB, marker string) *gorm.DB { return base.Select(marker) }},
+	{"Order", func(base *gorm.DB, marker string) *gorm.DB { return base.Order(marker) }},
+	{"Group", func(base *gorm.DB, marker string) *gorm.DB { return base.Group(marker) }},
+	{"Having", func(base *gorm.DB, marker string) *gorm.DB { return base.Having(marker + " = ?") }},
+	{"Joins", func(base *gorm.DB, marker string) *gorm.DB { return base.Joins(marker) }},
+	{"Clauses", func(base *gorm.DB, marker string) *gorm.DB { return base.Clauses(clause.Expr{SQL: marker}) }},
+}
+
+// runRaceTests reruns each raceProbe across raceGoroutines goroutines
+// sharing one base *gorm.DB and records RaceFree/RaceNote on the matching
+// MethodResult. It catches the same cross-talk scripts/concurrency
+// detects; run this survey binary with `go run -race` to additionally
+// catch the underlying data race itself.
+func runRaceTests(result *PurityResult) {
+	for _, p := range raceProbes {
+		raceFree, note := runRaceProbe(p)
+		m := result.Methods[p.Name]
+		m.RaceFree = ptr(raceFree)
+		m.RaceNote = note
+		result.Methods[p.Name] = m
+	}
+}
+
+// runRaceProbe shares one base *gorm.DB across raceGoroutines goroutines,
+// each applying p.Branch with a distinct marker and running a Finisher,
+// then checks whether any captured SQL contains more than one goroutine's
+// marker or is missing its own.
+func runRaceProbe(p raceProbe) (raceFree bool, note string) {
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return false, fmt.Sprintf("setup error: %v", err)
+	}
+	mock.MatchExpectationsInOrder(false)
+	for i := 0; i < raceGoroutines; i++ {
+		mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	}
+
+	base := db.Model(&User{}).Where("base_cond = ?", true)
+
+	markers := make([]string, raceGoroutines)
+	var wg sync.WaitGroup
+	for i := 0; i < raceGoroutines; i++ {
+		markers[i] = fmt.Sprintf("goroutine_%d_marker_col", i)
+		wg.Add(1)
+		go func(marker string) {
+			defer wg.Done()
+			var users []User
+			p.Branch(base, marker).Find(&users)
+		}(markers[i])
+	}
+	wg.Wait()
+
+	return evaluateRace(markers, cap.AllSQL())
+}
+
+// evaluateRace checks that each marker appears in exactly one captured
+// SQL statement and no statement contains more than one marker.
+func evaluateRace(markers, sqls []string) (bool, string) {
+	counts := make(map[string]int, len(markers))
+	for _, sql := range sqls {
+		normalized := strings.ToLower(sql)
+		present := 0
+		for _, m := range markers {
+			if strings.Contains(normalized, strings.ToLower(m)) {
+				counts[m]++
+				present++
+			}
+		}
+		if present > 1 {
+			return false, "multiple goroutines' markers accumulated in the same query (pollution under concurrency)"
+		}
+	}
+	for _, m := range markers {
+		if counts[m] != 1 {
+			return false, fmt.Sprintf("marker %q appeared %d time(s), want 1 (overwritten by a racing goroutine)", m, counts[m])
+		}
+	}
+	return true, "each goroutine's query contained only its own marker"
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/mpyw/gorm-purity-survey/report"
+)
+
+// ToReport converts result into a report.Report so it can be serialized as
+// JUnit XML or SARIF (see report.Report.WriteJUnit/WriteSARIF) for CI
+// pipelines that want to gate on "no new impure methods" without parsing
+// this binary's own JSON schema.
+func (result *PurityResult) ToReport() report.Report {
+	names := make([]string, 0, len(result.Methods))
+	for name := range result.Methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	r := report.Report{GormVersion: result.GormVersion, Driver: currentDialect}
+	for _, name := range names {
+		r.Findings = append(r.Findings, methodResultToFinding(result.Methods[name]))
+	}
+	return r
+}
+
+// methodResultToFinding maps one MethodResult onto the report package's
+// dimension-agnostic PurityFinding/Verdict shape.
+func methodResultToFinding(m MethodResult) report.PurityFinding {
+	f := report.PurityFinding{
+		Method:                 m.Name,
+		Category:               methodCategory(m),
+		CallbackIsolated:       firstNonNilBool(m.CallbackArgImmutable, m.TransactionCallbackImmutable),
+		ParentIsolated:         m.ImmutableReturn,
+		Clone:                  m.CallbackClone,
+		ReturnClone:            m.ReturnClone,
+		ImpureMode:             m.ImpureMode,
+		FinisherPreservesJoins: m.FinisherPreservesJoins,
+		Note:                   firstNonEmpty(m.Error, m.PureNote, m.CallbackNote, m.ImmutableNote, m.FinisherNote),
+	}
+	f.Verdict = methodVerdict(m)
+	f.Severity = methodSeverity(m)
+	return f
+}
+
+// schemaLeakMethods names the methods whose own pollution changes which
+// columns/schema a query touches rather than which rows it filters - see
+// methodSeverity's SeverityLeaksSchema case.
+var schemaLeakMethods = map[string]bool{
+	"Select":     true,
+	"Omit":       true,
+	"Distinct":   true,
+	"MapColumns": true,
+}
+
+// methodSeverity classifies what m's impurity, if any, actually leaked
+// (see report.Severity), reusing the dimensions MethodResult already
+// tracks instead of re-deriving the leak from raw SQL text: a leaked
+// callback argument (CallbackArgImmutable/TransactionCallbackImmutable/
+// CallbackStatementIsolated) ranks as SeverityLeaksCallbacks regardless of
+// which method it came from; a leaked Select/Omit/Distinct/MapColumns
+// ranks as SeverityLeaksSchema; every other leaked chain/finisher
+// dimension ranks as SeverityLeaksClause.
+func methodSeverity(m MethodResult) report.Severity {
+	if m.Error != "" {
+		return report.SeverityUnknown
+	}
+	if (m.CallbackArgImmutable != nil && !*m.CallbackArgImmutable) ||
+		(m.TransactionCallbackImmutable != nil && !*m.TransactionCallbackImmutable) ||
+		(m.CallbackStatementIsolated != nil && !*m.CallbackStatementIsolated) {
+		return report.SeverityLeaksCallbacks
+	}
+	if m.Pure != nil && !*m.Pure {
+		if schemaLeakMethods[m.Name] {
+			return report.SeverityLeaksSchema
+		}
+		return report.SeverityLeaksClause
+	}
+	if (m.ImmutableReturn != nil && !*m.ImmutableReturn) ||
+		(m.FinisherPreservesJoins != nil && !*m.FinisherPreservesJoins) ||
+		(m.FinisherPreservesPreloads != nil && !*m.FinisherPreservesPreloads) {
+		return report.SeverityLeaksClause
+	}
+	if m.Pure == nil && m.ImmutableReturn == nil && m.CallbackArgImmutable == nil && m.TransactionCallbackImmutable == nil {
+		return report.SeverityUnknown
+	}
+	return report.SeveritySafe
+}
+
+// methodCategory buckets a MethodResult the same way the tests/ package's
+// hand-written report.PurityFinding.Category values do: "callback" for
+// methods with a callback argument, "immutable-return" for
+// known-immutable-return methods with no Pure dimension, "chain" for
+// methods tested for receiver pollution, "finisher" otherwise.
+func methodCategory(m MethodResult) string {
+	switch {
+	case m.CallbackArgImmutable != nil || m.TransactionCallbackImmutable != nil:
+		return "callback"
+	case m.Pure == nil && m.ImmutableReturn != nil:
+		return "immutable-return"
+	case m.Pure != nil:
+		return "chain"
+	default:
+		return "finisher"
+	}
+}
+
+// methodVerdict reduces Pure, ImmutableReturn, CallbackArgImmutable,
+// TransactionCallbackImmutable and FinisherPreservesJoins down to a single
+// report.Verdict, treating any failing one as impure and a method with none
+// of them populated (and no error) as unknown. MethodResult's other
+// dimensions do not affect the verdict.
+func methodVerdict(m MethodResult) report.Verdict {
+	if m.Error != "" {
+		return report.VerdictUnknown
+	}
+	if m.Pure != nil && !*m.Pure {
+		return report.VerdictImpure
+	}
+	if m.ImmutableReturn != nil && !*m.ImmutableReturn {
+		return report.VerdictImpure
+	}
+	if m.CallbackArgImmutable != nil && !*m.CallbackArgImmutable {
+		return report.VerdictImpure
+	}
+	if m.TransactionCallbackImmutable != nil && !*m.TransactionCallbackImmutable {
+		return report.VerdictImpure
+	}
+	if m.FinisherPreservesJoins != nil && !*m.FinisherPreservesJoins {
+		return report.VerdictImpure
+	}
+	if m.Pure == nil && m.ImmutableReturn == nil && m.CallbackArgImmutable == nil && m.TransactionCallbackImmutable == nil && m.FinisherPreservesJoins == nil {
+		return report.VerdictUnknown
+	}
+	return report.VerdictPure
+}
+
+func firstNonNilBool(bs ...*bool) *bool {
+	for _, b := range bs {
+		if b != nil {
+			return b
+		}
+	}
+	return nil
+}
+
+func firstNonEmpty(ss ...string) string {
+	for _, s := range ss {
+		if s != "" {
+			return s
+		}
+	}
+	return ""
+}
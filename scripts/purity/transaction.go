@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+)
+
+// testTransactionCallback invokes db.Transaction(fn) twice with the same
+// closure, which appends a marker to its tx argument and runs a finisher,
+// capturing the callback's clone value on first entry. This is the same
+// callback-arg-immutability shape as the Preload regression (#7662): if
+// the tx passed to fn were shared/mutated across invocations, the
+// marker's occurrence count would grow on the second call instead of
+// staying flat.
+func testTransactionCallback(result *PurityResult) {
+	m := MethodResult{Name: "Transaction", Exists: true}
+	defer func() { result.Methods["Transaction"] = m }()
+
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	const marker = "tx_marker_col"
+	var callbackClone int = -1
+	callCount := 0
+	fn := func(tx *gorm.DB) error {
+		callCount++
+		if callCount == 1 {
+			callbackClone = getCloneValue(tx)
+		}
+		var users []User
+		tx.Where(marker+" = ?", true).Find(&users)
+		return nil
+	}
+
+	mock.ExpectBegin()
+	expectAnyQuery(mock)
+	mock.ExpectCommit()
+	if err := db.Transaction(fn); err != nil {
+		m.Error = err.Error()
+		return
+	}
+	firstCount := strings.Count(strings.ToLower(strings.Join(cap.AllSQL(), " ")), marker)
+	cap.Reset()
+
+	mock.ExpectBegin()
+	expectAnyQuery(mock)
+	mock.ExpectCommit()
+	if err := db.Transaction(fn); err != nil {
+		m.Error = err.Error()
+		return
+	}
+	secondCount := strings.Count(strings.ToLower(strings.Join(cap.AllSQL(), " ")), marker)
+
+	m.TransactionCallbackImmutable = ptr(secondCount <= firstCount)
+	if callbackClone >= 0 {
+		m.CallbackClone = ptr(callbackClone)
+	}
+	if m.TransactionCallbackImmutable != nil && !*m.TransactionCallbackImmutable {
+		m.CallbackNote = fmt.Sprintf("%s occurrence count grew from %d to %d across repeated db.Transaction(fn) calls with the same closure", marker, firstCount, secondCount)
+	} else {
+		m.CallbackNote = "Transaction callback's tx argument stayed isolated across repeated calls"
+	}
+}
+
+// testSavePoint verifies that SavePoint's returned *gorm.DB has its own
+// Statement rather than sharing the parent tx's, so branching off a
+// savepoint can't retroactively mutate the parent.
+func testSavePoint(result *PurityResult) {
+	m := MethodResult{Name: "SavePoint", Exists: true}
+	defer func() { result.Methods["SavePoint"] = m }()
+
+	db, mock, _, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	tx := db.Where("base_cond = ?", true)
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 0))
+	sp := tx.SavePoint("sp1")
+
+	m.ReturnClone = ptr(getCloneValue(sp))
+	m.ImmutableReturn = ptr(sp.Statement != tx.Statement)
+	if *m.ImmutableReturn {
+		m.ImmutableNote = "SavePoint returns a *gorm.DB with its own Statement, not shared with the parent tx"
+	} else {
+		m.ImmutableNote = "SavePoint's returned *gorm.DB shares Statement with the parent tx"
+	}
+}
+
+// testRollbackTo mirrors testSavePoint for RollbackTo's returned *gorm.DB.
+func testRollbackTo(result *PurityResult) {
+	m := MethodResult{Name: "RollbackTo", Exists: true}
+	defer func() { result.Methods["RollbackTo"] = m }()
+
+	db, mock, _, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	tx := db.Where("base_cond = ?", true)
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 0))
+	rb := tx.RollbackTo("sp1")
+
+	m.ReturnClone = ptr(getCloneValue(rb))
+	m.ImmutableReturn = ptr(rb.Statement != tx.Statement)
+	if *m.ImmutableReturn {
+		m.ImmutableNote = "RollbackTo returns a *gorm.DB with its own Statement, not shared with the parent tx"
+	} else {
+		m.ImmutableNote = "RollbackTo's returned *gorm.DB shares Statement with the parent tx"
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !gorm_v121plus
+
+package main
+
+// runVersionSpecificTests is a stub for pre-v1.21 GORM, which has none of
+// the version-gated methods (CreateInBatches, ToSQL, Connection, ...)
+// registered under the gorm_v121plus build tag and above.
+func runVersionSpecificTests(result *PurityResult) {
+	// Methods not available in this version
+}
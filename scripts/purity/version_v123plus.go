@@ -3,6 +3,8 @@
 package main
 
 import (
+	"database/sql"
+
 	"gorm.io/gorm"
 )
 
@@ -40,16 +42,75 @@ func testToSQL(result *PurityResult) {
 	base.Find(&users)
 
 	// Check if marker leaks to base
-	m.Pure = boolPtr(!cap.ContainsNormalized("POLLUTION_MARKER"))
+	m.Pure = ptr(!cap.ContainsNormalized("POLLUTION_MARKER"))
 	m.PureNote = "ToSQL generates SQL without execution"
 }
 
+// recordingConnPool wraps the mock *sql.DB that setupDB installs as
+// db.ConnPool, additionally implementing the GetDBConn accessor
+// db.Connection looks for to obtain a dedicated *sql.Conn for its callback's
+// scope. Without GetDBConn, db.ConnPool isn't recognized as conn-poolable
+// and Connection silently falls back to reusing the outer pool as-is -
+// exactly the silent-downgrade testConnection below needs to be able to
+// detect instead of assume.
+type recordingConnPool struct {
+	*sql.DB
+}
+
+func (p recordingConnPool) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
+
 func testConnection(result *PurityResult) {
 	m := MethodResult{Name: "Connection", Exists: true}
 	defer func() { result.Methods["Connection"] = m }()
 
-	// Connection runs a function with a dedicated connection
-	// Hard to test pollution without actual DB
-	m.Pure = boolPtr(true)
-	m.PureNote = "Connection creates isolated connection context"
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		m.Error = err.Error()
+		return
+	}
+
+	pool, ok := db.ConnPool.(*sql.DB)
+	if !ok {
+		m.Error = "db.ConnPool is not *sql.DB; can't install recordingConnPool"
+		return
+	}
+	db.ConnPool = recordingConnPool{DB: pool}
+	base := db.Model(&User{})
+
+	// Connection's callback runs on a dedicated connection - record whether
+	// its *gorm.DB still resolves to the same recordingConnPool the outer
+	// db uses (would mean no real isolation) or something else entirely
+	// (the dedicated *sql.Conn GetDBConn handed back).
+	var sharesOuterPool bool
+	expectAnyQuery(mock) // the callback's own Find, issued via the dedicated conn
+	connErr := db.Connection(func(tx *gorm.DB) error {
+		_, sharesOuterPool = tx.Statement.ConnPool.(recordingConnPool)
+		var inner []User
+		return tx.Where("marker = ?", "POLLUTION_MARKER").Find(&inner).Error
+	})
+	if connErr != nil {
+		m.Error = connErr.Error()
+		return
+	}
+
+	cap.Reset()
+	expectAnyQuery(mock) // the outer db's own Find, after Connection returns
+	var users []User
+	base.Find(&users)
+
+	m.Pure = ptr(!cap.ContainsNormalized("POLLUTION_MARKER"))
+	if m.Pure != nil && !*m.Pure {
+		m.PureNote = "Connection's callback Where leaked onto the outer *gorm.DB"
+	} else {
+		m.PureNote = "callback's Where against the dedicated connection left the outer *gorm.DB's later query untouched"
+	}
+
+	m.CallbackArgImmutable = ptr(!sharesOuterPool)
+	if sharesOuterPool {
+		m.CallbackNote = "Connection's callback *gorm.DB still resolved to the outer recordingConnPool - no dedicated connection was actually obtained"
+	} else {
+		m.CallbackNote = "Connection's callback *gorm.DB resolved to a connection handle distinct from the outer db.ConnPool"
+	}
 }
@@ -0,0 +1,13 @@
+// Package puritypbt property-tests GORM chain-method purity with
+// pgregory.net/rapid, generating random chains of builder methods with
+// rapid's generator instead of scripts/purity/fuzz.go's own random loop.
+// Shrinking then minimizes any counter-example rapid finds to the shortest
+// chain that still breaks isolation.
+//
+// rapid is an optional dependency, so the actual property test lives in
+// pbt_test.go behind the gorm_rapid build tag:
+//
+//	go test -tags=gorm_rapid ./scripts/puritypbt/...
+//
+// Without that tag this package has no tests to run.
+package puritypbt
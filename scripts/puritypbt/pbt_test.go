@@ -0,0 +1,155 @@
+//go:build gorm_rapid
+
+package puritypbt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"pgregory.net/rapid"
+
+	"github.com/mpyw/gorm-purity-survey/tests/capture"
+)
+
+// User and Profile mirror scripts/purity's test models so the SQL shapes
+// rapid generates against match the rest of the survey.
+type User struct {
+	ID      uint
+	Name    string
+	Role    string
+	Profile Profile
+}
+
+type Profile struct {
+	ID     uint
+	UserID uint
+}
+
+// probe is one chain method rapid can append to a generated sequence,
+// applying a distinct marker column/fragment so a leaked branch is
+// identifiable in captured SQL.
+type probe struct {
+	Name   string
+	Branch func(db *gorm.DB, marker string) *gorm.DB
+}
+
+// probes covers the methods named in the request: the builder surface
+// most likely to pollute a shared base when a derived chain is discarded
+// or interleaved with a sibling chain.
+var probes = []probe{
+	{"Where", func(db *gorm.DB, marker string) *gorm.DB { return db.Where(marker + " = ?", true) }},
+	{"Or", func(db *gorm.DB, marker string) *gorm.DB { return db.Or(marker + " = ?", true) }},
+	{"Not", func(db *gorm.DB, marker string) *gorm.DB { return db.Not(marker + " = ?", true) }},
+	{"Select", func(db *gorm.DB, marker string) *gorm.DB { return db.Select(marker) }},
+	{"Joins", func(db *gorm.DB, marker string) *gorm.DB { return db.Joins(marker) }},
+	{"Preload", func(db *gorm.DB, marker string) *gorm.DB {
+		return db.Preload("Profile", func(tx *gorm.DB) *gorm.DB { return tx.Where(marker + " = ?", true) })
+	}},
+	{"Group", func(db *gorm.DB, marker string) *gorm.DB { return db.Group(marker) }},
+	{"Having", func(db *gorm.DB, marker string) *gorm.DB { return db.Having(marker + " = ?", true) }},
+	{"Order", func(db *gorm.DB, marker string) *gorm.DB { return db.Order(marker) }},
+	{"Limit", func(db *gorm.DB, marker string) *gorm.DB { return db.Limit(len(marker)) }},
+	{"Offset", func(db *gorm.DB, marker string) *gorm.DB { return db.Offset(len(marker)) }},
+	{"Distinct", func(db *gorm.DB, marker string) *gorm.DB { return db.Distinct(marker) }},
+	{"Clauses", func(db *gorm.DB, marker string) *gorm.DB { return db.Clauses(clause.Expr{SQL: marker}) }},
+	{"Scopes", func(db *gorm.DB, marker string) *gorm.DB {
+		return db.Scopes(func(tx *gorm.DB) *gorm.DB { return tx.Where(marker + " = ?", true) })
+	}},
+}
+
+// setupDB opens a sqlmock-backed *gorm.DB under the mysql dialector,
+// scoped to this package so pbt_test.go doesn't need to
+// import scripts/purity (an unexported main package).
+func setupDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, *capture.SQLCapture) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	cap := capture.New()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: cap})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+	return db, mock, cap
+}
+
+// names renders a probe sequence as a readable chain for failure messages,
+// so a shrunk counter-example reads like "Where -> Joins -> Scopes".
+func names(seq []probe) string {
+	parts := make([]string, len(seq))
+	for i, p := range seq {
+		parts[i] = p.Name
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// TestPurityPBT_ChainIsolation generates two random chains of builder
+// methods off a shared base *gorm.DB and asserts that executing the left
+// chain never leaks its marker into the right chain's captured SQL, nor
+// into a query run against base itself afterward - the same isolation
+// guarantee scripts/purity/fuzz.go checks by hand, but explored by
+// rapid's generator and shrinker instead of fuzz.go's own random loop.
+func TestPurityPBT_ChainIsolation(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		depth := rapid.IntRange(2, 6).Draw(t, "depth")
+		pick := rapid.SampledFrom(probes)
+
+		var leftSeq, rightSeq []probe
+		for i := 0; i < depth; i++ {
+			leftSeq = append(leftSeq, pick.Draw(t, "left"))
+			rightSeq = append(rightSeq, pick.Draw(t, "right"))
+		}
+
+		db, mock, cap := setupDB(t)
+		base := db.Model(&User{})
+
+		const leftMarker = "left_branch_marker_col"
+		const rightMarker = "right_branch_marker_col"
+
+		left := base
+		for _, p := range leftSeq {
+			left = p.Branch(left, leftMarker)
+		}
+		mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		var lr []User
+		left.Find(&lr)
+
+		cap.Reset()
+
+		right := base
+		for _, p := range rightSeq {
+			right = p.Branch(right, rightMarker)
+		}
+		mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		var rr []User
+		right.Find(&rr)
+
+		if cap.ContainsNormalized(leftMarker) {
+			t.Fatalf("left chain (%s) leaked into right chain's (%s) SQL via shared base", names(leftSeq), names(rightSeq))
+		}
+
+		cap.Reset()
+
+		mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		var br []User
+		base.Find(&br)
+
+		if cap.ContainsNormalized(leftMarker) {
+			t.Fatalf("left chain (%s) leaked into shared base's own query", names(leftSeq))
+		}
+		if cap.ContainsNormalized(rightMarker) {
+			t.Fatalf("right chain (%s) leaked into shared base's own query", names(rightSeq))
+		}
+	})
+}
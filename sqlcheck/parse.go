@@ -0,0 +1,138 @@
+package sqlcheck
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	clauseSplitRe = regexp.MustCompile(`(?i)\s+(FROM|JOIN|LEFT JOIN|RIGHT JOIN|INNER JOIN|WHERE|GROUP BY|ORDER BY|LIMIT|OFFSET)\s+`)
+	andOrSplitRe  = regexp.MustCompile(`(?i)\s+(AND|OR)\s+`)
+	identRe       = regexp.MustCompile(`^[\s(]*"?([A-Za-z_][A-Za-z0-9_.]*)"?`)
+)
+
+// Parse parses a single SQL statement into a Statement. It's forgiving by
+// design: anything it can't confidently identify is just left zero-valued
+// rather than returned as an error, since callers use Statement as a best
+// effort structural view of sqlmock-captured SQL, not a validator.
+func Parse(sql string) *Statement {
+	s := &Statement{Raw: sql}
+
+	clauses, keywords := splitClauses(sql)
+	if len(clauses) == 0 {
+		return s
+	}
+
+	selectPart := clauses[0]
+	parseSelect(selectPart, s)
+
+	for i, kw := range keywords {
+		body := strings.TrimSpace(clauses[i+1])
+		switch strings.ToUpper(kw) {
+		case "FROM":
+			s.Table = firstIdent(body)
+		case "JOIN", "LEFT JOIN", "RIGHT JOIN", "INNER JOIN":
+			s.Joins = append(s.Joins, strings.TrimSpace(body))
+		case "WHERE":
+			s.Where = parsePredicate(body)
+		case "GROUP BY":
+			s.GroupBy = splitIdentList(body)
+		case "ORDER BY":
+			s.OrderBy = splitIdentList(body)
+		}
+	}
+
+	return s
+}
+
+// splitClauses splits sql on its top-level FROM/JOIN/WHERE/GROUP BY/ORDER
+// BY/LIMIT/OFFSET keywords, returning the clause bodies and the keywords
+// that introduced clauses[1:].
+func splitClauses(sql string) ([]string, []string) {
+	loc := clauseSplitRe.FindAllStringSubmatchIndex(sql, -1)
+	if loc == nil {
+		return []string{sql}, nil
+	}
+
+	var clauses []string
+	var keywords []string
+	prev := 0
+	for _, m := range loc {
+		clauses = append(clauses, sql[prev:m[0]])
+		keywords = append(keywords, sql[m[2]:m[3]])
+		prev = m[1]
+	}
+	clauses = append(clauses, sql[prev:])
+	return clauses, keywords
+}
+
+// parseSelect reads DISTINCT and the column list out of the SELECT
+// clause (selectPart still has the leading "SELECT" keyword in it).
+func parseSelect(selectPart string, s *Statement) {
+	body := selectPart
+	if idx := strings.Index(strings.ToUpper(body), "SELECT"); idx >= 0 {
+		body = body[idx+len("SELECT"):]
+	}
+	body = strings.TrimSpace(body)
+
+	upper := strings.ToUpper(body)
+	if strings.HasPrefix(upper, "DISTINCT") {
+		s.Distinct = true
+		body = strings.TrimSpace(body[len("DISTINCT"):])
+	}
+
+	s.Columns = splitIdentList(body)
+}
+
+// splitIdentList splits a comma-separated list of (possibly quoted,
+// possibly qualified) identifiers.
+func splitIdentList(body string) []string {
+	parts := strings.Split(body, ",")
+	var out []string
+	for _, p := range parts {
+		if id := firstIdent(p); id != "" {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// firstIdent extracts the leading identifier from s, stripping quotes.
+func firstIdent(s string) string {
+	m := identRe.FindStringSubmatch(s)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// parsePredicate splits body on top-level AND/OR into a left-associative
+// Predicate tree. Parenthesized sub-groups are not descended into
+// separately; the identifier inside is still picked up by firstIdent, so
+// ReferencesColumn still finds columns wrapped in a single group like
+// "(col = ? AND other = ?)".
+func parsePredicate(body string) *Predicate {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil
+	}
+
+	parts := andOrSplitRe.Split(body, -1)
+	ops := andOrSplitRe.FindAllStringSubmatch(body, -1)
+	if len(parts) == 0 {
+		return nil
+	}
+
+	root := leafPredicate(parts[0])
+	for i, op := range ops {
+		right := leafPredicate(parts[i+1])
+		root = &Predicate{Op: strings.ToUpper(op[1]), Left: root, Right: right}
+	}
+	return root
+}
+
+// leafPredicate builds a leaf Predicate from one condition's text by
+// extracting the first identifier as its column.
+func leafPredicate(cond string) *Predicate {
+	return &Predicate{Column: firstIdent(cond)}
+}
@@ -0,0 +1,130 @@
+// Package sqlcheck parses captured SQL into a small structural
+// representation so pollution checks can assert "does the WHERE tree
+// reference column X" or "is DISTINCT present" instead of doing a
+// substring search over the raw SQL text, which produces false positives
+// (a marker word happening to appear inside an unrelated literal) and
+// false negatives (GORM's clause builder rewriting a column reference in
+// a way that no longer contains the literal marker string).
+//
+// This is a small hand-rolled parser scoped to the shapes GORM's default
+// clause builder + sqlmock actually produce in this repo (SELECT lists,
+// a single FROM table, JOINs, an AND/OR WHERE tree, GROUP BY, ORDER BY,
+// DISTINCT) rather than a full general-purpose SQL grammar - a real
+// dialect-complete parser (e.g. pingcap/parser) would be substantially
+// more capable, but is a much bigger dependency than the well-formed,
+// narrow SQL shapes this survey ever captures actually need.
+package sqlcheck
+
+import (
+	"strings"
+)
+
+// Predicate is one node of a WHERE tree: either a leaf referencing a
+// column, or an AND/OR combination of two sub-predicates.
+type Predicate struct {
+	Op     string // "AND", "OR", or "" for a leaf
+	Column string // populated for leaves
+	Left   *Predicate
+	Right  *Predicate
+}
+
+// Columns returns every column referenced anywhere in the predicate tree.
+func (p *Predicate) Columns() []string {
+	if p == nil {
+		return nil
+	}
+	if p.Op == "" {
+		if p.Column == "" {
+			return nil
+		}
+		return []string{p.Column}
+	}
+	return append(p.Left.Columns(), p.Right.Columns()...)
+}
+
+// References reports whether the predicate tree references col
+// (case-insensitive).
+func (p *Predicate) References(col string) bool {
+	for _, c := range p.Columns() {
+		if strings.EqualFold(c, col) {
+			return true
+		}
+	}
+	return false
+}
+
+// Statement is the structural subset of a SELECT statement sqlcheck can
+// answer pollution questions about.
+type Statement struct {
+	Distinct bool
+	Columns  []string
+	Table    string
+	Joins    []string
+	Where    *Predicate
+	GroupBy  []string
+	OrderBy  []string
+	Raw      string
+}
+
+// HasTable reports whether table is the FROM table or appears in a JOIN.
+func (s *Statement) HasTable(table string) bool {
+	if s == nil {
+		return false
+	}
+	if strings.EqualFold(s.Table, table) {
+		return true
+	}
+	for _, j := range s.Joins {
+		if strings.Contains(strings.ToLower(j), strings.ToLower(table)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReferencesColumn reports whether col appears in the WHERE tree, the
+// SELECT list, GROUP BY, or ORDER BY.
+func (s *Statement) ReferencesColumn(col string) bool {
+	if s == nil {
+		return false
+	}
+	if s.Where.References(col) {
+		return true
+	}
+	for _, list := range [][]string{s.Columns, s.GroupBy, s.OrderBy} {
+		for _, c := range list {
+			if strings.EqualFold(c, col) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Diff returns the columns referenced in branch's WHERE tree but not in
+// base's - the conditions a derived query picked up that its parent didn't
+// have, i.e. what a pollution finding actually leaked. Columns in other
+// clauses are not compared.
+func Diff(base, branch *Statement) []string {
+	if branch == nil {
+		return nil
+	}
+	baseCols := make(map[string]bool)
+	if base != nil {
+		for _, c := range base.Where.Columns() {
+			baseCols[strings.ToLower(c)] = true
+		}
+	}
+
+	var out []string
+	seen := make(map[string]bool)
+	for _, c := range branch.Where.Columns() {
+		lc := strings.ToLower(c)
+		if baseCols[lc] || seen[lc] {
+			continue
+		}
+		seen[lc] = true
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// === Concurrency-Pollution Tests ===
+//
+// The pollution tests in pollution_test.go compare a Statement before and after a single
+// sequential mutation. They miss the realistic case of a *gorm.DB handle
+// shared across goroutines - e.g. a handle stored on an application struct
+// that dispatches requests concurrently, or a plugin like go-gorm/caches'
+// easer running the same chain from many goroutines at once. If a method
+// mutates its receiver, concurrent callers racing on the same
+// Statement.Clauses/Selects/Joins maps produce nondeterministic SQL (and,
+// under `go test -race`, a race failure) instead of each goroutine getting
+// its own query. Run this file with `-race` to get the data-race half of
+// the guarantee; runConcurrencyCase checks the cross-talk half.
+
+// concurrencyGoroutines is how many goroutines race against the shared base
+// DB in each case below.
+const concurrencyGoroutines = 8
+
+// runConcurrencyCase shares one base *gorm.DB across concurrencyGoroutines
+// goroutines. Each goroutine calls branch with its own marker column and
+// runs a Finisher against sqlmock. It then asserts that every goroutine's
+// captured SQL contains only its own marker - if two goroutines' markers
+// ever land in the same query, or a marker goes missing entirely, the
+// method pollutes its receiver under concurrent use.
+func runConcurrencyCase(t *testing.T, name string, branch func(base *gorm.DB, marker string) *gorm.DB) {
+	t.Helper()
+	t.Run(name, func(t *testing.T) {
+		db, mock, cap := setupDB(t)
+		mock.MatchExpectationsInOrder(false)
+		for i := 0; i < concurrencyGoroutines; i++ {
+			expectAnyQuery(mock)
+		}
+
+		base := db.Model(&User{}).Where("base_cond = ?", true)
+
+		markers := make([]string, concurrencyGoroutines)
+		var wg sync.WaitGroup
+		for i := 0; i < concurrencyGoroutines; i++ {
+			markers[i] = fmt.Sprintf("goroutine_%d_marker_col", i)
+			wg.Add(1)
+			go func(marker string) {
+				defer wg.Done()
+				var users []User
+				branch(base, marker).Find(&users)
+			}(markers[i])
+		}
+		wg.Wait()
+
+		counts := make(map[string]int, len(markers))
+		for _, sql := range cap.AllSQL() {
+			normalized := strings.ToLower(sql)
+			present := 0
+			for _, m := range markers {
+				if strings.Contains(normalized, strings.ToLower(m)) {
+					counts[m]++
+					present++
+				}
+			}
+			if present > 1 {
+				t.Errorf("%s: multiple goroutines' markers appeared in the same query: %s", name, sql)
+			}
+		}
+		for _, m := range markers {
+			if counts[m] != 1 {
+				t.Errorf("%s: marker %q appeared %d time(s) across captured SQL, want 1 (receiver pollution races across goroutines)", name, m, counts[m])
+			}
+		}
+	})
+}
+
+// TestConcurrency_Where checks Where for cross-talk when the same base
+// *gorm.DB is shared across goroutines.
+func TestConcurrency_Where(t *testing.T) {
+	runConcurrencyCase(t, "Where", func(base *gorm.DB, marker string) *gorm.DB {
+		return base.Where(marker + " = ?")
+	})
+}
+
+// TestConcurrency_Clauses checks Clauses, which mutates Statement.Clauses -
+// the map the request singles out as a likely race target.
+func TestConcurrency_Clauses(t *testing.T) {
+	runConcurrencyCase(t, "Clauses", func(base *gorm.DB, marker string) *gorm.DB {
+		return base.Clauses(clause.Expr{SQL: marker})
+	})
+}
+
+// TestConcurrency_Joins checks Joins, which mutates Statement.Joins.
+func TestConcurrency_Joins(t *testing.T) {
+	runConcurrencyCase(t, "Joins", func(base *gorm.DB, marker string) *gorm.DB {
+		return base.Joins(marker)
+	})
+}
+
+// TestConcurrency_Select checks Select, which mutates Statement.Selects.
+func TestConcurrency_Select(t *testing.T) {
+	runConcurrencyCase(t, "Select", func(base *gorm.DB, marker string) *gorm.DB {
+		return base.Select(marker)
+	})
+}
@@ -0,0 +1,150 @@
+//go:build gorm_v130plus
+
+// Package generics mirrors the chain-isolation, callback-isolation and
+// same-instance tests in the tests package for the Generics API
+// (gorm.G[T], PreloadBuilder, JoinBuilder) introduced in GORM v1.30. It is
+// only built against GORM versions that export the API under test.
+//
+// The critical question the Generics API raises is whether the fluent
+// gorm.G[T] value genuinely provides value semantics, or whether the
+// internal *gorm.DB it wraps is still shared the way the pre-generics
+// chain is - see methods.GenericMethods for the full list this package
+// works through.
+package generics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/mpyw/gorm-purity-survey/tests/capture"
+)
+
+// User is the test model shared by every case in this package.
+type User struct {
+	ID   uint
+	Name string
+	Role string
+}
+
+func setupDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, *capture.SQLCapture) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	cap := capture.New()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: cap})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	return db, mock, cap
+}
+
+// TestGenerics_Where_ValueSemantics checks whether branching off a
+// gorm.G[User] value after Where keeps the branches independent, the same
+// way TestImmutableReturn_Where checks the pre-generics chain.
+func TestGenerics_Where_ValueSemantics(t *testing.T) {
+	db, mock, cap := setupDB(t)
+
+	base := gorm.G[User](db).Where("base = ?", true)
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	if _, err := base.Where("branch_one_col = ?", true).Find(context.Background()); err != nil {
+		t.Logf("Find error (expected with mock rows): %v", err)
+	}
+
+	cap.Reset()
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	if _, err := base.Where("branch_two_col = ?", true).Find(context.Background()); err != nil {
+		t.Logf("Find error (expected with mock rows): %v", err)
+	}
+
+	if cap.ContainsNormalized("branch_one_col") {
+		t.Error("gorm.G[T].Where does NOT provide value semantics: branches interfere (internal *gorm.DB is shared)")
+	} else {
+		t.Log("gorm.G[T].Where provides value semantics: branches are independent")
+	}
+}
+
+// TestGenerics_Preload_BuilderIsolation checks whether a PreloadBuilder
+// callback's mutations leak into subsequent, unrelated queries the way
+// Preload's pre-generics func(*gorm.DB) callback can (see bug #7662 in
+// scripts/purity).
+func TestGenerics_Preload_BuilderIsolation(t *testing.T) {
+	db, mock, cap := setupDB(t)
+
+	callCount := 0
+	preload := func(pb gorm.PreloadBuilder) error {
+		callCount++
+		pb.Where("fixed_callback_marker_col = ?", true)
+		return nil
+	}
+
+	q := gorm.G[User](db).Preload("Profile", preload)
+
+	mock.ExpectQuery(".*users.*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+	if _, err := q.Find(context.Background()); err != nil {
+		t.Logf("first Find error: %v", err)
+	}
+
+	firstCount := len(cap.AllSQL())
+	cap.Reset()
+
+	mock.ExpectQuery(".*users.*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}))
+	if _, err := q.Find(context.Background()); err != nil {
+		t.Logf("second Find error: %v", err)
+	}
+
+	secondCount := len(cap.AllSQL())
+	if secondCount > firstCount {
+		t.Errorf("PreloadBuilder callback accumulates across calls: first=%d second=%d queries", firstCount, secondCount)
+	}
+	t.Logf("PreloadBuilder callback invoked %d time(s)", callCount)
+}
+
+// TestGenerics_JoinBuilder_SameInstance checks whether the JoinBuilder
+// passed to a Joins callback is the same instance across repeated builds
+// of the same gorm.G[T] value, the dangerous pattern the pre-generics
+// Scopes/Transaction callbacks are tested for in tests/callback_test.go.
+func TestGenerics_JoinBuilder_SameInstance(t *testing.T) {
+	db, mock, _ := setupDB(t)
+
+	var seen []gorm.JoinBuilder
+	join := func(jb gorm.JoinBuilder, joinTable, curTable interface{}) error {
+		seen = append(seen, jb)
+		jb.Where("in_join = ?", true)
+		return nil
+	}
+
+	q := gorm.G[User](db).Joins("Profile", join)
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	if _, err := q.Find(context.Background()); err != nil {
+		t.Logf("Find error: %v", err)
+	}
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	if _, err := q.Find(context.Background()); err != nil {
+		t.Logf("Find error: %v", err)
+	}
+
+	if len(seen) == 2 && seen[0] == seen[1] {
+		t.Log("WARNING: JoinBuilder callback receives the SAME instance across repeated Find calls")
+	} else {
+		t.Log("JoinBuilder callback receives a distinct instance per Find call")
+	}
+}
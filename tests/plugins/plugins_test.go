@@ -0,0 +1,151 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/mpyw/gorm-purity-survey/report"
+	"github.com/mpyw/gorm-purity-survey/tests/capture"
+)
+
+// User is the test model shared by every plugin scenario in this package.
+type User struct {
+	ID   uint
+	Name string
+	Role string
+}
+
+// scenario names one plugin combination under test.
+type scenario struct {
+	name    string
+	plugins []gorm.Plugin
+}
+
+var scenarios = []scenario{
+	{name: "none"},
+	{name: "caches", plugins: []gorm.Plugin{NewCacherStub(), NewEaserStub()}},
+	{name: "resolver", plugins: []gorm.Plugin{ResolverStub{}}},
+	{name: "caches+resolver", plugins: []gorm.Plugin{NewCacherStub(), NewEaserStub(), ResolverStub{}}},
+}
+
+// setupDB opens a sqlmock-backed *gorm.DB with the given plugins installed.
+func setupDB(t *testing.T, plugins ...gorm.Plugin) (*gorm.DB, sqlmock.Sqlmock, *capture.SQLCapture) {
+	t.Helper()
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+
+	cap := capture.New()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: cap})
+	if err != nil {
+		t.Fatalf("failed to open gorm: %v", err)
+	}
+
+	for _, p := range plugins {
+		if err := db.Use(p); err != nil {
+			t.Fatalf("failed to install plugin %s: %v", p.Name(), err)
+		}
+	}
+
+	return db, mock, cap
+}
+
+// TestPluginMatrix_ChainIsolation reruns the Where chain-isolation check
+// once per plugin combination, recording a PurityFinding for each so a
+// plugin that breaks the vanilla isolation guarantee shows up in the
+// report the same way a GORM version regression would.
+func TestPluginMatrix_ChainIsolation(t *testing.T) {
+	rec := report.NewRecorder("plugin-matrix")
+
+	for _, sc := range scenarios {
+		sc := sc
+		t.Run(sc.name, func(t *testing.T) {
+			db, mock, cap := setupDB(t, sc.plugins...)
+
+			base := db.Session(&gorm.Session{}).Model(&User{})
+			q := base.Where("base = ?", true)
+
+			mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+			var r1 []User
+			q.Where("branch_one_col = ?", true).Find(&r1)
+
+			cap.Reset()
+
+			mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+			var r2 []User
+			q.Where("branch_two_col = ?", true).Find(&r2)
+
+			isolated := !cap.ContainsNormalized("branch_one_col")
+			f := report.PurityFinding{
+				Method:         "Where",
+				Category:       "chain",
+				GormVersion:    sc.name,
+				ParentIsolated: &isolated,
+			}
+			if isolated {
+				f.Verdict = report.VerdictPure
+			} else {
+				f.Verdict = report.VerdictImpure
+				f.Note = "plugin " + sc.name + " breaks Where's immutable-return guarantee (branches interfere)"
+			}
+			rec.Record(f)
+		})
+	}
+}
+
+// TestPluginMatrix_CallbackIsolation reruns the Scopes callback-isolation
+// check per plugin combination. This is the sharper of the two checks,
+// because EaserStub deliberately shares a single in-flight *gorm.DB, which
+// is exactly the shape of bug CallbackMethods()/Scopes is meant to catch.
+func TestPluginMatrix_CallbackIsolation(t *testing.T) {
+	rec := report.NewRecorder("plugin-matrix")
+
+	for _, sc := range scenarios {
+		sc := sc
+		t.Run(sc.name, func(t *testing.T) {
+			db, mock, _ := setupDB(t, sc.plugins...)
+
+			base := db.Session(&gorm.Session{}).Model(&User{})
+
+			var callbackDB *gorm.DB
+			scoped := base.Scopes(func(tx *gorm.DB) *gorm.DB {
+				callbackDB = tx
+				return tx.Where("scope = ?", true)
+			})
+
+			mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+			var users []User
+			scoped.Find(&users)
+
+			sameInstance := callbackDB == base
+			f := report.PurityFinding{
+				Method:       "Scopes",
+				Category:     "callback",
+				GormVersion:  sc.name,
+				SameInstance: &sameInstance,
+			}
+			if sameInstance {
+				f.Verdict = report.VerdictImpure
+				f.Note = "plugin " + sc.name + " causes Scopes callback to receive the SAME *gorm.DB as the parent"
+			} else {
+				f.Verdict = report.VerdictPure
+			}
+			rec.Record(f)
+
+			t.Logf("scenario=%s sameInstance=%v", sc.name, sameInstance)
+		})
+	}
+
+	for _, f := range rec.Report().ImpureFindings() {
+		t.Errorf("plugin isolation regression: %s", f.Note)
+	}
+}
@@ -0,0 +1,238 @@
+package plugins
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/mpyw/gorm-purity-survey/report"
+)
+
+// === Registered-Callback Purity Tests ===
+//
+// stubs.go registers plugin-shaped callbacks at fixed points (Before
+// "gorm:query") to test whether *installing* a plugin changes an existing
+// method's isolation guarantee. This file tests the callback registration
+// points themselves: for each (operation, phase) a raw probe is registered
+// via db.Callback().<Op>().<Phase>(...).Register(...), the same entry point
+// go-gorm/caches' Before/After hooks use to rewrite SQL or short-circuit
+// execution. If the *gorm.DB handed to that probe has clone=0, mutations the
+// plugin makes inside it accumulate into the *next* request that shares the
+// same base handle instead of being isolated to the request that triggered
+// the callback.
+
+// getCloneValue extracts the unexported clone field from *gorm.DB.
+// Returns -1 if the field doesn't exist.
+func getCloneValue(db *gorm.DB) int {
+	rv := reflect.ValueOf(db).Elem()
+	cloneField := rv.FieldByName("clone")
+	if !cloneField.IsValid() {
+		return -1
+	}
+	return int(cloneField.Int())
+}
+
+// registeredCallbackOp describes one db.Callback() processor under test:
+// how to register a probe at a given phase, and how to trigger it via
+// sqlmock.
+type registeredCallbackOp struct {
+	name     string
+	register func(db *gorm.DB, phase, id string, fn func(*gorm.DB)) error
+	trigger  func(db *gorm.DB, mock sqlmock.Sqlmock)
+}
+
+var registeredCallbackOps = []registeredCallbackOp{
+	{
+		name: "Query",
+		register: func(db *gorm.DB, phase, id string, fn func(*gorm.DB)) error {
+			cb := db.Callback().Query()
+			if phase == "Before" {
+				return cb.Before("gorm:query").Register(id, fn)
+			}
+			return cb.After("gorm:query").Register(id, fn)
+		},
+		trigger: func(db *gorm.DB, mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+			var users []User
+			db.Find(&users)
+		},
+	},
+	{
+		name: "Create",
+		register: func(db *gorm.DB, phase, id string, fn func(*gorm.DB)) error {
+			cb := db.Callback().Create()
+			if phase == "Before" {
+				return cb.Before("gorm:create").Register(id, fn)
+			}
+			return cb.After("gorm:create").Register(id, fn)
+		},
+		trigger: func(db *gorm.DB, mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+			db.Create(&User{Name: "probe"})
+		},
+	},
+	{
+		name: "Update",
+		register: func(db *gorm.DB, phase, id string, fn func(*gorm.DB)) error {
+			cb := db.Callback().Update()
+			if phase == "Before" {
+				return cb.Before("gorm:update").Register(id, fn)
+			}
+			return cb.After("gorm:update").Register(id, fn)
+		},
+		trigger: func(db *gorm.DB, mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			db.Model(&User{}).Where("id = ?", 1).Update("name", "updated")
+		},
+	},
+	{
+		name: "Delete",
+		register: func(db *gorm.DB, phase, id string, fn func(*gorm.DB)) error {
+			cb := db.Callback().Delete()
+			if phase == "Before" {
+				return cb.Before("gorm:delete").Register(id, fn)
+			}
+			return cb.After("gorm:delete").Register(id, fn)
+		},
+		trigger: func(db *gorm.DB, mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectCommit()
+			db.Where("id = ?", 1).Delete(&User{})
+		},
+	},
+	{
+		name: "Row",
+		register: func(db *gorm.DB, phase, id string, fn func(*gorm.DB)) error {
+			cb := db.Callback().Row()
+			if phase == "Before" {
+				return cb.Before("gorm:row").Register(id, fn)
+			}
+			return cb.After("gorm:row").Register(id, fn)
+		},
+		trigger: func(db *gorm.DB, mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			db.Model(&User{}).Row()
+		},
+	},
+	{
+		name: "Raw",
+		register: func(db *gorm.DB, phase, id string, fn func(*gorm.DB)) error {
+			cb := db.Callback().Raw()
+			if phase == "Before" {
+				return cb.Before("gorm:raw").Register(id, fn)
+			}
+			return cb.After("gorm:raw").Register(id, fn)
+		},
+		trigger: func(db *gorm.DB, mock sqlmock.Sqlmock) {
+			mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			var ids []int
+			db.Raw("SELECT id FROM users").Scan(&ids)
+		},
+	},
+}
+
+// registeredCallbackPhases lists the phases GORM's callback processor
+// actually supports. "Around" (wrap both sides of the operation in one
+// registration) isn't one of them - Before/After are the only registration
+// kinds gorm.CallbackProcessor exposes - so it isn't in this table; see the
+// note on TestRegisteredCallback_CloneByOperation.
+var registeredCallbackPhases = []string{"Before", "After"}
+
+// TestRegisteredCallback_CloneByOperation registers a probe callback at
+// every (operation, phase) pair gorm.Callback() exposes and records the
+// clone value of the *gorm.DB handed to it, keyed the same way the
+// scripts/purity clone-value table keys its CallbackClone column.
+//
+// "Around" isn't tested: gorm.CallbackProcessor only exposes Before/After
+// registration, not a single wrapping hook, so a plugin that wants
+// around-style behavior has to pair a Before and an After registration
+// under the same name - which is exactly what the Before+After rows below
+// already cover.
+func TestRegisteredCallback_CloneByOperation(t *testing.T) {
+	rec := report.NewRecorder("registered-callback")
+
+	for _, op := range registeredCallbackOps {
+		op := op
+		for _, phase := range registeredCallbackPhases {
+			phase := phase
+			t.Run(op.name+"/"+phase, func(t *testing.T) {
+				db, mock, _ := setupDB(t)
+
+				var probeClone int
+				var probeDB *gorm.DB
+				id := "probe:" + op.name + ":" + phase
+				if err := op.register(db, phase, id, func(tx *gorm.DB) {
+					probeDB = tx
+					probeClone = getCloneValue(tx)
+				}); err != nil {
+					t.Fatalf("registering %s/%s probe: %v", op.name, phase, err)
+				}
+
+				op.trigger(db, mock)
+
+				if probeDB == nil {
+					t.Fatalf("%s/%s probe was never invoked", op.name, phase)
+				}
+
+				f := report.PurityFinding{
+					Method:   op.name,
+					Category: "registered-callback-" + phase,
+					Clone:    &probeClone,
+				}
+				if probeClone == 0 {
+					f.Verdict = report.VerdictImpure
+					f.Note = "probe's *gorm.DB has clone=0 (shares Statement); plugin mutations inside this callback accumulate across requests"
+				} else {
+					f.Verdict = report.VerdictPure
+				}
+				rec.Record(f)
+
+				t.Logf("%s/%s: clone=%d", op.name, phase, probeClone)
+			})
+		}
+	}
+
+	for _, f := range rec.Report().Findings {
+		t.Logf("%-6s %-22s clone=%v verdict=%s", f.Method, f.Category, *f.Clone, f.Verdict)
+	}
+}
+
+// TestRegisteredCallback_MutationLeaksAcrossExecutions checks the second
+// half of the request: does a mutation a plugin's Before-Query callback
+// makes to its *gorm.DB leak into a second, unrelated execution that shares
+// the same base handle - the shape of bug a clone=0 callback argument
+// enables.
+func TestRegisteredCallback_MutationLeaksAcrossExecutions(t *testing.T) {
+	db, mock, cap := setupDB(t)
+
+	if err := db.Callback().Query().Before("gorm:query").Register("probe:mutate", func(tx *gorm.DB) {
+		tx.Statement.Where("leaked_marker_col = ?", true)
+	}); err != nil {
+		t.Fatalf("registering probe: %v", err)
+	}
+
+	base := db.Session(&gorm.Session{}).Model(&User{})
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r1 []User
+	base.Find(&r1)
+
+	cap.Reset()
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r2 []User
+	base.Find(&r2)
+
+	if cap.ContainsNormalized("leaked_marker_col") {
+		t.Log("registered Before-Query callback mutation LEAKS across executions sharing the same base handle")
+	} else {
+		t.Log("registered Before-Query callback mutation does NOT leak across executions")
+	}
+}
@@ -0,0 +1,105 @@
+// Package plugins reruns the chain/callback/finisher isolation suite once
+// per registered plugin combination, using lightweight in-memory stand-ins
+// for the kind of callbacks real users layer on top of *gorm.DB: a
+// request-coalescing/response-caching plugin (go-gorm/caches) and a
+// connection-resolving plugin (dbresolver).
+// None of these talk to a real cache or pool - they exist only
+// to register gorm.Callback hooks with the same shape real plugins use, so
+// the purity survey can tell whether *installing a plugin* changes whether
+// a chain/callback/finisher method keeps its isolation guarantees.
+package plugins
+
+import (
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// CacherStub mimics go-gorm/caches: it intercepts the query callback and,
+// on a cache hit, reuses the previously captured *gorm.Statement by
+// reference rather than cloning it - the exact hazard the real plugin's
+// "cloned Statement" design is meant to avoid, reproduced here so the
+// survey can detect a regression toward sharing.
+type CacherStub struct {
+	mu    sync.Mutex
+	store map[string]*gorm.Statement
+}
+
+// NewCacherStub creates an empty CacherStub.
+func NewCacherStub() *CacherStub {
+	return &CacherStub{store: make(map[string]*gorm.Statement)}
+}
+
+// Name implements gorm.Plugin.
+func (c *CacherStub) Name() string { return "caches:stub" }
+
+// Initialize implements gorm.Plugin, registering a before-query callback
+// that records (and, on repeat SQL, shares) the query's Statement.
+func (c *CacherStub) Initialize(db *gorm.DB) error {
+	return db.Callback().Query().Before("gorm:query").Register("caches:stub:before", func(tx *gorm.DB) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		key := tx.Statement.SQL.String()
+		if key == "" {
+			return
+		}
+		if cached, ok := c.store[key]; ok {
+			// Deliberately share by reference: this is the bug shape the
+			// real plugin avoids via a deep clone.
+			tx.Statement = cached
+			return
+		}
+		c.store[key] = tx.Statement
+	})
+}
+
+// EaserStub mimics the request-coalescing "easer" half of go-gorm/caches: a
+// single *gorm.DB is shared across calls for the same key instead of each
+// caller getting its own isolated handle.
+type EaserStub struct {
+	mu       sync.Mutex
+	inFlight map[string]*gorm.DB
+}
+
+// NewEaserStub creates an empty EaserStub.
+func NewEaserStub() *EaserStub {
+	return &EaserStub{inFlight: make(map[string]*gorm.DB)}
+}
+
+// Name implements gorm.Plugin.
+func (e *EaserStub) Name() string { return "caches:easer:stub" }
+
+// Initialize implements gorm.Plugin, registering a before-query callback
+// keyed by table name: the first *gorm.DB seen for a table is kept and
+// copied over every later query on that table. Entries are never removed,
+// so the sharing is not limited to queries running at the same time.
+func (e *EaserStub) Initialize(db *gorm.DB) error {
+	return db.Callback().Query().Before("gorm:query").Register("caches:easer:stub:before", func(tx *gorm.DB) {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		key := tx.Statement.Table
+		if shared, ok := e.inFlight[key]; ok {
+			*tx = *shared
+			return
+		}
+		e.inFlight[key] = tx
+	})
+}
+
+// ResolverStub mimics dbresolver's registration shape: it registers a
+// before-query callback at the point where a sources/replicas resolver
+// would pick a connection, but the callback changes nothing, not even
+// ConnPool.
+type ResolverStub struct{}
+
+// Name implements gorm.Plugin.
+func (ResolverStub) Name() string { return "dbresolver:stub" }
+
+// Initialize implements gorm.Plugin.
+func (ResolverStub) Initialize(db *gorm.DB) error {
+	return db.Callback().Query().Before("gorm:query").Register("dbresolver:stub:before", func(tx *gorm.DB) {
+		// Real dbresolver picks a *gorm.DB clone backed by a different
+		// ConnPool here; the stub is a no-op marker so the suite still
+		// observes a plugin-free baseline of isolation.
+	})
+}
@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+
+	"github.com/mpyw/gorm-purity-survey/report"
+)
+
+// reportPath is set via `go test -run TestSurvey -args -report=out.json`.
+// When empty, TestSurvey still runs (and still fails the build on a
+// regression) but doesn't write a report file.
+var reportPath = flag.String("report", "", "write a JSON purity report to this path")
+
+// gormVersion mirrors the env/file lookup used by the scripts/purity and
+// scripts/methods enumerators, so the report's GormVersion field lines up
+// with the matrix runner's per-version output.
+func gormVersion() string {
+	if v := os.Getenv("GORM_VERSION"); v != "" {
+		return v
+	}
+	return "unknown"
+}
+
+// TestSurvey drives a representative subset of the purity checks through
+// report.Recorder and, when -report is set, serializes the result to JSON.
+// It complements rather than replaces the TestPollution_*/TestCallback_*
+// tests in pollution_test.go and callback_test.go: those are read as t.Log output during development, this is
+// the CI-consumable counterpart for the same checks.
+func TestSurvey(t *testing.T) {
+	rec := report.NewRecorder(gormVersion())
+
+	surveyWhere(t, rec)
+	surveySession(t, rec)
+	surveyScopesCallback(t, rec)
+	surveyTransactionCallback(t, rec)
+
+	if *reportPath != "" {
+		f, err := os.Create(*reportPath)
+		if err != nil {
+			t.Fatalf("failed to create report file: %v", err)
+		}
+		defer f.Close()
+		if err := rec.Report().WriteJSON(f); err != nil {
+			t.Fatalf("failed to write report: %v", err)
+		}
+	}
+
+	for _, f := range rec.Report().ImpureFindings() {
+		t.Errorf("regression: %s (%s) is impure: %s", f.Method, f.Category, f.Note)
+	}
+}
+
+func surveyWhere(t *testing.T, rec *report.Recorder) {
+	t.Helper()
+	db, mock, cap := setupDB(t)
+
+	base := db.Session(&gorm.Session{}).Model(&User{})
+	base.Where("pollution_marker_col = ?", true)
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var users []User
+	base.Find(&users)
+
+	pure := !cap.ContainsNormalized("pollution_marker_col")
+	f := report.PurityFinding{Method: "Where", Category: "chain"}
+	if pure {
+		f.Verdict = report.VerdictPure
+	} else {
+		f.Verdict = report.VerdictImpure
+		f.Note = "Where polluted the receiver"
+	}
+	rec.Record(f)
+}
+
+func surveySession(t *testing.T, rec *report.Recorder) {
+	t.Helper()
+	db, mock, cap := setupDB(t)
+
+	q := db.Session(&gorm.Session{}).Model(&User{})
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r1 []User
+	q.Where("branch = ?", "one").Find(&r1)
+
+	cap.Reset()
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r2 []User
+	q.Where("branch = ?", "two").Find(&r2)
+
+	immutable := !cap.ContainsNormalized("one")
+	f := report.PurityFinding{Method: "Session", Category: "immutable-return", ParentIsolated: &immutable}
+	if immutable {
+		f.Verdict = report.VerdictPure
+	} else {
+		f.Verdict = report.VerdictImpure
+		f.Note = "Session return value is mutable (branches interfere)"
+	}
+	rec.Record(f)
+}
+
+func surveyScopesCallback(t *testing.T, rec *report.Recorder) {
+	t.Helper()
+	db, mock, cap := setupDB(t)
+
+	base := db.Session(&gorm.Session{}).Model(&User{})
+	scoped := base.Scopes(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("role = ?", "admin")
+	})
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r1 []User
+	scoped.Find(&r1)
+
+	cap.Reset()
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r2 []User
+	base.Find(&r2)
+
+	isolated := !cap.ContainsNormalized("admin")
+	f := report.PurityFinding{Method: "Scopes", Category: "callback", CallbackIsolated: &isolated}
+	if isolated {
+		f.Verdict = report.VerdictPure
+	} else {
+		f.Verdict = report.VerdictImpure
+		f.Note = "Scopes callback leaked into parent"
+	}
+	rec.Record(f)
+}
+
+func surveyTransactionCallback(t *testing.T, rec *report.Recorder) {
+	t.Helper()
+	db, mock, cap := setupDB(t)
+
+	base := db.Session(&gorm.Session{}).Model(&User{}).Where("base = ?", true)
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	_ = base.Transaction(func(tx *gorm.DB) error {
+		tx.Where("in_tx = ?", true)
+		return nil
+	})
+
+	cap.Reset()
+
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var users []User
+	base.Find(&users)
+
+	isolated := !cap.ContainsNormalized("in_tx")
+	f := report.PurityFinding{Method: "Transaction", Category: "callback", CallbackIsolated: &isolated}
+	if isolated {
+		f.Verdict = report.VerdictPure
+	} else {
+		f.Verdict = report.VerdictImpure
+		f.Note = "Transaction callback leaked into parent"
+	}
+	rec.Record(f)
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/mpyw/gorm-purity-survey/report"
+	"github.com/mpyw/gorm-purity-survey/versions"
+)
+
+// versionsReportPath is set via `go test -run TestVersionsMatrix -args
+// -versions-report=out.json`, mirroring -report on TestSurvey.
+var versionsReportPath = flag.String("versions-report", "", "write a JSON versions-matrix report to this path")
+
+// TestVersionsMatrix runs every versions.MethodProbe compatible with the
+// GORM version this test binary was built against (selected by the
+// gorm_vNNNplus build tags versions/ uses) and, when -versions-report is
+// set, writes the results as a report.Report so a driver script can merge
+// one file per version into a single matrix the same way cmd/purity-matrix
+// merges TestSurvey's output.
+func TestVersionsMatrix(t *testing.T) {
+	rec := report.NewRecorder(gormVersion())
+
+	for _, p := range versions.Methods() {
+		f := report.PurityFinding{
+			Method:   p.Method,
+			Category: "version-probe",
+			Clone:    p.CallbackClone,
+			Note:     p.Note,
+		}
+		if p.ImmutableReturn != nil {
+			f.ParentIsolated = p.ImmutableReturn
+		}
+		switch {
+		case p.Pure == nil:
+			f.Verdict = report.VerdictUnknown
+		case *p.Pure:
+			f.Verdict = report.VerdictPure
+		default:
+			f.Verdict = report.VerdictImpure
+		}
+		rec.Record(f)
+	}
+
+	if *versionsReportPath != "" {
+		f, err := os.Create(*versionsReportPath)
+		if err != nil {
+			t.Fatalf("failed to create versions-matrix report file: %v", err)
+		}
+		defer f.Close()
+		if err := rec.Report().WriteJSON(f); err != nil {
+			t.Fatalf("failed to write versions-matrix report: %v", err)
+		}
+	}
+
+	for _, f := range rec.Report().Findings {
+		t.Logf("%s (%s): verdict=%s note=%s", f.Method, f.Category, f.Verdict, f.Note)
+	}
+}
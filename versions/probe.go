@@ -0,0 +1,154 @@
+// Package versions probes the GORM chain methods that were added (or that
+// changed isolation semantics) in a specific minor release, so a matrix
+// runner can tell which methods changed behavior between releases instead
+// of treating the whole survey as one flat list - the same ecosystem
+// problem projects like Gitea hit chasing xorm 0.8 -> 1.0 -> 1.0.2
+// breakage.
+//
+// One file per release that introduces a tracked method is gated behind
+// the same gorm_vNNNplus build tags scripts/purity already uses
+// (gorm_v121plus, gorm_v123plus, gorm_v125plus, gorm_v126plus). Releases
+// with no tracked method addition (v1.20, v1.22, v1.24) have no file here,
+// the same way scripts/purity has no version_v1XXplus.go for them - v1.20
+// is simply the floor every build satisfies, covered by baseMethods.
+package versions
+
+import (
+	"database/sql"
+	"reflect"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+
+	"github.com/mpyw/gorm-purity-survey/tests/capture"
+)
+
+// MethodProbe is one method's purity result for a single version build -
+// the unit a matrix runner keys its report by (gormVersion, method).
+type MethodProbe struct {
+	Method          string
+	Pure            *bool
+	ImmutableReturn *bool
+	CallbackClone   *int
+	Note            string
+}
+
+// Methods runs every probe compatible with the GORM version this package
+// was built against (selected via the gorm_vNNNplus build tags) and returns
+// one MethodProbe per method.
+func Methods() []MethodProbe {
+	return append(baseMethods(), versionSpecificMethods()...)
+}
+
+// baseMethods covers methods present since v1.20, the floor every build of
+// this package satisfies.
+func baseMethods() []MethodProbe {
+	return []MethodProbe{probeWhere(), probeScopes()}
+}
+
+// MockDialector is a minimal dialector for sqlmock testing (PostgreSQL style).
+type MockDialector struct {
+	Conn *sql.DB
+}
+
+func (d MockDialector) Name() string { return "postgres" }
+func (d MockDialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = d.Conn
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+func (d MockDialector) Migrator(db *gorm.DB) gorm.Migrator    { return migrator.Migrator{} }
+func (d MockDialector) DataTypeOf(field *schema.Field) string { return "TEXT" }
+func (d MockDialector) DefaultValueOf(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "NULL"}
+}
+func (d MockDialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, v interface{}) {
+	writer.WriteByte('?')
+}
+func (d MockDialector) QuoteTo(writer clause.Writer, str string) {
+	writer.WriteByte('"')
+	writer.WriteString(str)
+	writer.WriteByte('"')
+}
+func (d MockDialector) Explain(sql string, vars ...interface{}) string { return sql }
+
+// User is the test model shared by every probe in this package.
+type User struct {
+	ID   uint
+	Name string
+	Role string
+}
+
+// setupDB creates a GORM DB with sqlmock and SQL capture.
+func setupDB() (*gorm.DB, sqlmock.Sqlmock, *capture.SQLCapture, error) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cap := capture.New()
+	db, err := gorm.Open(MockDialector{Conn: mockDB}, &gorm.Config{Logger: cap.LogMode(4)})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return db, mock, cap, nil
+}
+
+// expectAnyQuery sets up mock to accept any query.
+func expectAnyQuery(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+}
+
+// ptr returns a pointer to the given value (generic helper).
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// getCloneValue extracts the unexported clone field from *gorm.DB.
+// Returns -1 if the field doesn't exist.
+func getCloneValue(db *gorm.DB) int {
+	rv := reflect.ValueOf(db).Elem()
+	cloneField := rv.FieldByName("clone")
+	if !cloneField.IsValid() {
+		return -1
+	}
+	return int(cloneField.Int())
+}
+
+// probeWhere probes Where the same way scripts/purity's testWhere does.
+func probeWhere() MethodProbe {
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return MethodProbe{Method: "Where", Note: err.Error()}
+	}
+
+	base := db.Model(&User{}).Where("base_cond = ?", true)
+	base.Where("pollution_marker_col = ?", true)
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	return MethodProbe{Method: "Where", Pure: ptr(!cap.ContainsNormalized("pollution_marker_col"))}
+}
+
+// probeScopes probes the Scopes callback's clone value, the (gormVersion,
+// method, callbackClone) row the request calls out explicitly.
+func probeScopes() MethodProbe {
+	db, mock, _, err := setupDB()
+	if err != nil {
+		return MethodProbe{Method: "Scopes", Note: err.Error()}
+	}
+
+	var cbClone int
+	expectAnyQuery(mock)
+	var users []User
+	db.Model(&User{}).Scopes(func(tx *gorm.DB) *gorm.DB {
+		cbClone = getCloneValue(tx)
+		return tx.Where("in_scope = ?", true)
+	}).Find(&users)
+
+	return MethodProbe{Method: "Scopes", CallbackClone: &cbClone}
+}
@@ -0,0 +1,33 @@
+//go:build gorm_v121plus
+
+package versions
+
+import "github.com/DATA-DOG/go-sqlmock"
+
+// versionSpecificMethods adds CreateInBatches (added in v1.21) and chains
+// into the v1.23+ set.
+func versionSpecificMethods() []MethodProbe {
+	return append([]MethodProbe{probeCreateInBatches()}, v123Methods()...)
+}
+
+// probeCreateInBatches mirrors scripts/purity's testCreateInBatches.
+func probeCreateInBatches() MethodProbe {
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return MethodProbe{Method: "CreateInBatches", Note: err.Error()}
+	}
+
+	base := db.Model(&User{})
+
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(1, 2))
+	users := []User{{Name: "test1"}, {Name: "test2"}}
+	base.Where("marker = ?", "POLLUTION_MARKER").CreateInBatches(&users, 10)
+
+	cap.Reset()
+
+	mock.ExpectExec(".*").WillReturnResult(sqlmock.NewResult(3, 2))
+	users2 := []User{{Name: "test3"}, {Name: "test4"}}
+	base.Where("second = ?", "clean").CreateInBatches(&users2, 10)
+
+	return MethodProbe{Method: "CreateInBatches", Pure: ptr(!cap.ContainsNormalized("POLLUTION_MARKER"))}
+}
@@ -0,0 +1,7 @@
+//go:build !gorm_v121plus
+
+package versions
+
+// versionSpecificMethods is a stub for v1.20, which predates
+// CreateInBatches/ToSQL/Connection/InnerJoins/MapColumns.
+func versionSpecificMethods() []MethodProbe { return nil }
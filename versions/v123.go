@@ -0,0 +1,104 @@
+//go:build gorm_v123plus
+
+package versions
+
+import (
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// v123Methods adds ToSQL and Connection (added in v1.23) and chains into
+// the v1.25+ set.
+func v123Methods() []MethodProbe {
+	return append([]MethodProbe{probeToSQL(), probeConnection()}, v125Methods()...)
+}
+
+// probeToSQL mirrors scripts/purity's testToSQL.
+func probeToSQL() MethodProbe {
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return MethodProbe{Method: "ToSQL", Note: err.Error()}
+	}
+
+	base := db.Model(&User{})
+	base.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("marker = ?", "POLLUTION_MARKER").Find(&[]User{})
+	})
+
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	return MethodProbe{
+		Method: "ToSQL",
+		Pure:   ptr(!cap.ContainsNormalized("POLLUTION_MARKER")),
+		Note:   "ToSQL generates SQL without execution",
+	}
+}
+
+// recordingConnPool mirrors scripts/purity's version_v123plus.go: it wraps
+// the mock *sql.DB that setupDB installs as db.ConnPool, additionally
+// implementing the GetDBConn accessor db.Connection looks for to obtain a
+// dedicated *sql.Conn for its callback's scope. Without GetDBConn,
+// db.ConnPool isn't recognized as conn-poolable and Connection silently
+// falls back to reusing the outer pool as-is.
+type recordingConnPool struct {
+	*sql.DB
+}
+
+func (p recordingConnPool) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
+
+// probeConnection mirrors scripts/purity's testConnection: it installs
+// recordingConnPool, runs db.Connection's callback against a marker query,
+// and checks both that the marker doesn't leak onto the outer *gorm.DB and
+// that the callback's *gorm.DB actually resolved to a connection handle
+// distinct from the outer pool, instead of assuming Connection() isolates.
+func probeConnection() MethodProbe {
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return MethodProbe{Method: "Connection", Note: err.Error()}
+	}
+
+	pool, ok := db.ConnPool.(*sql.DB)
+	if !ok {
+		return MethodProbe{Method: "Connection", Note: "db.ConnPool is not *sql.DB; can't install recordingConnPool"}
+	}
+	db.ConnPool = recordingConnPool{DB: pool}
+	base := db.Model(&User{})
+
+	var sharesOuterPool bool
+	expectAnyQuery(mock) // the callback's own Find, issued via the dedicated conn
+	connErr := db.Connection(func(tx *gorm.DB) error {
+		_, sharesOuterPool = tx.Statement.ConnPool.(recordingConnPool)
+		var inner []User
+		return tx.Where("marker = ?", "POLLUTION_MARKER").Find(&inner).Error
+	})
+	if connErr != nil {
+		return MethodProbe{Method: "Connection", Note: connErr.Error()}
+	}
+
+	cap.Reset()
+	expectAnyQuery(mock) // the outer db's own Find, after Connection returns
+	var users []User
+	base.Find(&users)
+
+	note := "callback's Where against the dedicated connection left the outer *gorm.DB's later query untouched"
+	if cap.ContainsNormalized("POLLUTION_MARKER") {
+		note = "Connection's callback Where leaked onto the outer *gorm.DB"
+	}
+	if sharesOuterPool {
+		note += "; callback *gorm.DB still resolved to the outer recordingConnPool - no dedicated connection was actually obtained"
+	} else {
+		note += "; callback *gorm.DB resolved to a connection handle distinct from the outer db.ConnPool"
+	}
+
+	return MethodProbe{
+		Method:          "Connection",
+		Pure:            ptr(!cap.ContainsNormalized("POLLUTION_MARKER")),
+		ImmutableReturn: ptr(!sharesOuterPool),
+		Note:            note,
+	}
+}
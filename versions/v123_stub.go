@@ -0,0 +1,6 @@
+//go:build gorm_v121plus && !gorm_v123plus
+
+package versions
+
+// v123Methods is a stub for v1.21-v1.22 (ToSQL, Connection not available).
+func v123Methods() []MethodProbe { return nil }
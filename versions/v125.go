@@ -0,0 +1,44 @@
+//go:build gorm_v125plus
+
+package versions
+
+import "github.com/DATA-DOG/go-sqlmock"
+
+// v125Methods adds InnerJoins (added in v1.25) and chains into the v1.26+
+// set.
+func v125Methods() []MethodProbe {
+	return append([]MethodProbe{probeInnerJoins()}, v126Methods()...)
+}
+
+// probeInnerJoins mirrors scripts/purity's testInnerJoins.
+func probeInnerJoins() MethodProbe {
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return MethodProbe{Method: "InnerJoins", Note: err.Error()}
+	}
+
+	base := db.Model(&User{})
+	base.InnerJoins("POLLUTION_MARKER")
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+	pure := !cap.ContainsNormalized("POLLUTION_MARKER")
+
+	db2, mock2, cap2, err := setupDB()
+	if err != nil {
+		return MethodProbe{Method: "InnerJoins", Pure: &pure}
+	}
+
+	q := db2.Model(&User{}).InnerJoins("base_join")
+	mock2.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r1 []User
+	q.InnerJoins("BRANCH_ONE").Find(&r1)
+
+	cap2.Reset()
+	mock2.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "role"}))
+	var r2 []User
+	q.InnerJoins("BRANCH_TWO").Find(&r2)
+
+	immutable := !cap2.ContainsNormalized("BRANCH_ONE")
+	return MethodProbe{Method: "InnerJoins", Pure: &pure, ImmutableReturn: &immutable}
+}
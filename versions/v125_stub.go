@@ -0,0 +1,6 @@
+//go:build gorm_v123plus && !gorm_v125plus
+
+package versions
+
+// v125Methods is a stub for v1.23-v1.24 (InnerJoins not available).
+func v125Methods() []MethodProbe { return nil }
@@ -0,0 +1,28 @@
+//go:build gorm_v126plus
+
+package versions
+
+// v126Methods adds MapColumns (added in v1.26).
+func v126Methods() []MethodProbe {
+	return []MethodProbe{probeMapColumns()}
+}
+
+// probeMapColumns mirrors scripts/purity's testMapColumns.
+func probeMapColumns() MethodProbe {
+	db, mock, cap, err := setupDB()
+	if err != nil {
+		return MethodProbe{Method: "MapColumns", Note: err.Error()}
+	}
+
+	base := db.Model(&User{})
+	base.MapColumns(map[string]string{"name": "POLLUTION_MARKER"})
+	expectAnyQuery(mock)
+	var users []User
+	base.Find(&users)
+
+	return MethodProbe{
+		Method: "MapColumns",
+		Pure:   ptr(!cap.ContainsNormalized("POLLUTION_MARKER")),
+		Note:   "MapColumns modifies column mapping",
+	}
+}
@@ -0,0 +1,6 @@
+//go:build gorm_v125plus && !gorm_v126plus
+
+package versions
+
+// v126Methods is a stub for v1.25 (MapColumns not available).
+func v126Methods() []MethodProbe { return nil }